@@ -0,0 +1,269 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mongoose84/proser/filesystem"
+	"github.com/mongoose84/proser/input"
+	"github.com/mongoose84/proser/language"
+)
+
+// Exit codes returned by App.Run
+const (
+	// ExitOK means the command succeeded
+	ExitOK = 0
+	// ExitStale means generated files are out of date (check, diff) or were kept (clean)
+	ExitStale = 1
+	// ExitUsage means the command line was invalid
+	ExitUsage = 2
+	// ExitFailure means the command failed
+	ExitFailure = 3
+)
+
+// App runs proser commands against injected input, filesystem and output,
+// so it can be driven from scripts and tests without spawning the binary
+type App struct {
+	In        input.InputCollector // answers interactive questions and conflict prompts
+	FS        filesystem.FileSystem
+	Out       io.Writer
+	Languages *language.Registry // languages used to detect the stack and write the guidelines of a project
+}
+
+// userLanguagesDir returns the directory holding the user's own language definitions,
+// $XDG_CONFIG_HOME/proser/languages or ~/.config/proser/languages, or "" without a home
+func userLanguagesDir() string {
+	if config := os.Getenv("XDG_CONFIG_HOME"); config != "" {
+		return filepath.Join(config, "proser", "languages")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "proser", "languages")
+}
+
+// New creates an App using the default language registry
+func New(in input.InputCollector, fs filesystem.FileSystem, out io.Writer) *App {
+	return &App{In: in, FS: fs, Out: out, Languages: language.NewDefaultRegistry()}
+}
+
+// loadLanguages extends Languages with the definitions in the user's language directory
+// and then in the target's .proser/languages, so project definitions win
+func (a *App) loadLanguages(absTarget string) error {
+	registry := a.Languages.Clone()
+	for _, dir := range []string{userLanguagesDir(), filepath.Join(absTarget, filepath.FromSlash(language.ProjectDir))} {
+		if dir == "" {
+			continue
+		}
+		if err := registry.LoadDir(a.FS, dir); err != nil {
+			return fmt.Errorf("invalid language definitions: %w", err)
+		}
+	}
+	a.Languages = registry
+	return nil
+}
+
+// command is a proser subcommand. run registers its flags on the given flag set,
+// parses args and performs the command.
+type command struct {
+	name    string
+	args    string // positional arguments shown in the usage line
+	summary string
+	run     func(a *App, flags *flag.FlagSet, args []string) error
+}
+
+// commands lists every subcommand in the order shown by help
+var commands = []command{
+	{"init", "[target-path]", "Ask questions and generate PROSE files (default command)", (*App).runInit},
+	{"update", "[target-path]", "Regenerate all files from the saved .proser.yaml without prompting", (*App).runUpdate},
+	{"check", "[target-path]", "Exit non-zero if generated files are missing or out of date", (*App).runCheck},
+	{"diff", "[target-path]", "Print a unified diff between the files on disk and what update would write", (*App).runDiff},
+	{"clean", "[target-path]", "Remove the files proser generated and prune empty directories", (*App).runClean},
+	{"list", "", "List project types and the generators they run", (*App).runList},
+	{"schema", "", "Print the JSON Schema of language definition files", (*App).runSchema},
+	{"version", "", "Print the proser version", (*App).runVersion},
+}
+
+// exitError ends a command with a specific exit code.
+// A nil err means the outcome was already reported.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("exit code %d", e.code)
+	}
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// usageErrorf reports an invalid command line
+func usageErrorf(format string, args ...interface{}) error {
+	return &exitError{code: ExitUsage, err: fmt.Errorf(format, args...)}
+}
+
+// errStale ends check, diff and clean after they reported files that need attention
+var errStale = &exitError{code: ExitStale}
+
+// Run executes a command line (without the program name) and returns the exit code.
+// Without a known command the arguments are passed to init, so `proser [path]` keeps working.
+func (a *App) Run(args []string) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "help", "-h", "-help", "--help":
+			return a.runHelp(args[1:])
+		}
+	}
+
+	cmd, rest, err := a.resolve(args)
+	if err != nil {
+		a.printf("❌ %v\n\n", err)
+		a.printUsage()
+		return ExitUsage
+	}
+
+	flags := flag.NewFlagSet("proser "+cmd.name, flag.ContinueOnError)
+	flags.SetOutput(a.Out)
+	flags.Usage = func() { a.printCommandUsage(cmd, flags) }
+
+	err = cmd.run(a, flags, rest)
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return ExitOK
+	}
+
+	var exit *exitError
+	if errors.As(err, &exit) {
+		if exit.err != nil {
+			a.printf("❌ %v\n\n", exit.err)
+			if exit.code == ExitUsage {
+				flags.Usage()
+			}
+		}
+		return exit.code
+	}
+	a.printf("❌ %v\n", err)
+	return ExitFailure
+}
+
+// resolve picks the command named by the first argument, falling back to init
+// for flags, paths and an empty command line
+func (a *App) resolve(args []string) (command, []string, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return commands[0], args, nil
+	}
+	if cmd, ok := lookupCommand(args[0]); ok {
+		return cmd, args[1:], nil
+	}
+	if looksLikePath(a.FS, args[0]) {
+		return commands[0], args, nil
+	}
+	return command{}, nil, fmt.Errorf("unknown command %q", args[0])
+}
+
+// lookupCommand returns the command with the given name
+func lookupCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// looksLikePath reports whether arg should be treated as a target path rather than a command
+func looksLikePath(fs filesystem.FileSystem, arg string) bool {
+	if strings.ContainsAny(arg, `/\`) || strings.HasPrefix(arg, ".") {
+		return true
+	}
+	_, err := fs.Stat(arg)
+	return err == nil
+}
+
+// runHelp prints general help, or the help of a single command
+func (a *App) runHelp(args []string) int {
+	if len(args) == 0 {
+		a.printUsage()
+		return ExitOK
+	}
+	if _, ok := lookupCommand(args[0]); !ok {
+		a.printf("❌ unknown command %q\n\n", args[0])
+		a.printUsage()
+		return ExitUsage
+	}
+	return a.Run([]string{args[0], "-h"})
+}
+
+// printUsage displays the list of commands
+func (a *App) printUsage() {
+	a.println("Usage: proser <command> [options] [target-path]")
+	a.println()
+	a.println("Commands:")
+	for _, cmd := range commands {
+		a.printf("  %-9s %s\n", cmd.name, cmd.summary)
+	}
+	a.println()
+	a.println("Run `proser help <command>` or `proser <command> -h` for the options of a command.")
+	a.println("Without a command, proser runs init, so `proser [options] [target-path]` still works.")
+	a.println()
+	a.println("Exit codes:")
+	a.printf("  %d  success\n", ExitOK)
+	a.printf("  %d  generated files are out of date (check, diff) or modified files were kept (clean)\n", ExitStale)
+	a.printf("  %d  invalid command line\n", ExitUsage)
+	a.printf("  %d  the command failed\n", ExitFailure)
+	a.println()
+	a.println("Description:")
+	a.println("  PROSER generates GitHub Copilot PROSE files for your project.")
+	a.println("  It creates .github/copilot-instructions.md, .instructions.md files,")
+	a.println("  and an AGENTS.md file at the project root based on your configuration.")
+	a.println("  The resolved configuration is saved to .proser.yaml for later updates.")
+	a.println()
+	a.println("Examples:")
+	a.println("  proser                    # Setup in current directory")
+	a.println("  proser init /path/to/project")
+	a.println("  proser init --config answers.yaml /path/to/project")
+	a.println("  proser init --project-type backend --backend-language Go --yes")
+	a.println("  proser update             # Refresh files after upgrading proser")
+	a.println("  proser update --on-conflict backup --generator-policy backend-instructions=skip")
+	a.println("  proser check              # Fail CI when generated files are stale")
+}
+
+// printCommandUsage displays the usage and flags of a single command
+func (a *App) printCommandUsage(cmd command, flags *flag.FlagSet) {
+	a.printf("%s\n\n", strings.TrimSpace("Usage: proser "+cmd.name+" [options] "+cmd.args))
+	a.println(cmd.summary)
+
+	hasFlags := false
+	flags.VisitAll(func(*flag.Flag) { hasFlags = true })
+	if hasFlags {
+		a.println()
+		a.println("Options:")
+		flags.PrintDefaults()
+	}
+}
+
+// printf writes formatted output
+func (a *App) printf(format string, args ...interface{}) {
+	fmt.Fprintf(a.Out, format, args...)
+}
+
+// println writes its arguments followed by a newline
+func (a *App) println(args ...interface{}) {
+	fmt.Fprintln(a.Out, args...)
+}
+
+// printBanner displays the tool banner shown by the commands that generate files
+func (a *App) printBanner() {
+	a.println("===========================================")
+	a.println("PROSER - PROSE File Setup Tool")
+	a.println("===========================================")
+	a.println()
+}
@@ -0,0 +1,374 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mongoose84/proser/config"
+	"github.com/mongoose84/proser/filesystem"
+	"github.com/mongoose84/proser/generator"
+	"github.com/mongoose84/proser/input"
+)
+
+const target = "/project"
+
+// newTestApp returns an app on an in-memory filesystem holding an empty target directory.
+// The user's language directory is pointed at a path that does not exist.
+func newTestApp(t *testing.T, in input.InputCollector) (*App, *filesystem.MemoryFileSystem, *bytes.Buffer) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", "/nonexistent")
+	fsys := filesystem.NewMemoryFileSystem()
+	if err := fsys.MkdirAll(target, 0755); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	return New(in, fsys, &out), fsys, &out
+}
+
+// run executes a command line and fails the test unless it exits with want
+func run(t *testing.T, app *App, out *bytes.Buffer, want int, args ...string) string {
+	t.Helper()
+	out.Reset()
+	if code := app.Run(args); code != want {
+		t.Fatalf("Run(%q) = %d, want %d; output:\n%s", args, code, want, out.String())
+	}
+	return out.String()
+}
+
+// initBackend generates a Go backend project without prompting
+func initBackend(t *testing.T, app *App, out *bytes.Buffer) {
+	t.Helper()
+	run(t, app, out, ExitOK, "init", "--project-type", "backend", "--backend-language", "Go", "--yes", target)
+}
+
+func readFile(t *testing.T, fsys filesystem.FileSystem, relPath string) string {
+	t.Helper()
+	data, err := fsys.ReadFile(filepath.Join(target, relPath))
+	if err != nil {
+		t.Fatalf("reading %s: %v", relPath, err)
+	}
+	return string(data)
+}
+
+func writeFile(t *testing.T, fsys filesystem.FileSystem, relPath, content string) {
+	t.Helper()
+	if err := fsys.WriteFile(filepath.Join(target, relPath), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func exists(fsys filesystem.FileSystem, relPath string) bool {
+	_, err := fsys.Stat(filepath.Join(target, relPath))
+	return err == nil
+}
+
+func TestInitYes(t *testing.T) {
+	app, fsys, out := newTestApp(t, input.NewFileCollector(nil))
+	initBackend(t, app, out)
+
+	for _, relPath := range []string{
+		"AGENTS.md",
+		".github/copilot-instructions.md",
+		".github/instructions/backend.instructions.md",
+		config.FileName,
+		generator.ManifestPath,
+	} {
+		if !exists(fsys, relPath) {
+			t.Errorf("%s was not written", relPath)
+		}
+	}
+	if got := readFile(t, fsys, ".github/instructions/backend.instructions.md"); !strings.Contains(got, `applyTo: "**/*.go"`) {
+		t.Errorf("backend instructions do not apply to Go files:\n%s", got)
+	}
+	if got := readFile(t, fsys, config.FileName); !strings.Contains(got, "backend_language: Go") {
+		t.Errorf("saved configuration lacks the language flag:\n%s", got)
+	}
+}
+
+func TestInitInteractive(t *testing.T) {
+	// Project type, quick setup, then the essential backend questions; the misspelled
+	// language is rejected once and corrected
+	answers := strings.Join([]string{
+		"backend",
+		"yes",
+		"scripted", "", "", "",
+		"Goo", "Go",
+		"", "", "",
+	}, "\n") + "\n"
+	app, fsys, out := newTestApp(t, nil)
+	app.In = input.NewInteractiveCollector(strings.NewReader(answers), out)
+
+	got := run(t, app, out, ExitOK, "init", "--no-detect", target)
+	if !strings.Contains(got, "did you mean Go?") {
+		t.Errorf("output lacks the language suggestion:\n%s", got)
+	}
+
+	saved := readFile(t, fsys, config.FileName)
+	for _, want := range []string{"project_name: scripted", "backend_language: Go\n"} {
+		if !strings.Contains(saved, want) {
+			t.Errorf("saved configuration lacks %q:\n%s", want, saved)
+		}
+	}
+}
+
+func TestInitAnswersFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string // empty for a missing file
+	}{
+		{name: "missing file"},
+		{name: "invalid YAML", content: "project_type: [backend\n"},
+		{name: "unknown key", content: "project_type: backend\nbackend_langauge: Go\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, fsys, out := newTestApp(t, input.NewFileCollector(nil))
+			if tt.content != "" {
+				writeFile(t, fsys, "answers.yaml", tt.content)
+			}
+			run(t, app, out, ExitUsage, "init", "--config", filepath.Join(target, "answers.yaml"), target)
+			if exists(fsys, config.FileName) {
+				t.Errorf("%s written despite the invalid answers file", config.FileName)
+			}
+		})
+	}
+}
+
+func TestInitUnknownProjectType(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "flag", args: []string{"--project-type", "bakend", "--yes"}},
+		{name: "answers file", args: []string{"--config", filepath.Join(target, "answers.yaml")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, fsys, out := newTestApp(t, input.NewFileCollector(nil))
+			writeFile(t, fsys, "answers.yaml", "project_type: bakend\n")
+			args := append([]string{"init"}, tt.args...)
+			got := run(t, app, out, ExitUsage, append(args, target)...)
+			if !strings.Contains(got, `unknown project type "bakend"`) {
+				t.Errorf("output lacks the unknown project type:\n%s", got)
+			}
+			if exists(fsys, "AGENTS.md") {
+				t.Error("files were generated for an unknown project type")
+			}
+		})
+	}
+}
+
+func TestInitPromptPolicyNeedsInput(t *testing.T) {
+	for _, args := range [][]string{
+		{"--on-conflict", "prompt"},
+		{"--generator-policy", "agents-md=prompt"},
+	} {
+		app, fsys, out := newTestApp(t, input.NewFileCollector(nil))
+		cmd := append([]string{"init", "--project-type", "backend", "--yes"}, args...)
+		got := run(t, app, out, ExitUsage, append(cmd, target)...)
+		if !strings.Contains(got, "needs interactive input") {
+			t.Errorf("%q: output lacks the reason:\n%s", args, got)
+		}
+		if exists(fsys, "AGENTS.md") {
+			t.Errorf("%q: files were generated", args)
+		}
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	app, fsys, out := newTestApp(t, input.NewFileCollector(nil))
+	initBackend(t, app, out)
+
+	writeFile(t, fsys, "AGENTS.md", readFile(t, fsys, "AGENTS.md")+"\n## Team Notes\nhand-written\n")
+	if err := fsys.Remove(filepath.Join(target, ".github/instructions/backend.instructions.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	got := run(t, app, out, ExitOK, "update", target)
+	for _, want := range []string{"AGENTS.md was modified by hand", "backend.instructions.md was deleted"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("update output lacks %q:\n%s", want, got)
+		}
+	}
+	if !exists(fsys, ".github/instructions/backend.instructions.md") {
+		t.Error("update did not restore the deleted file")
+	}
+	if !strings.Contains(readFile(t, fsys, "AGENTS.md"), "## Team Notes") {
+		t.Error("update dropped the section outside the managed regions")
+	}
+}
+
+func TestUpdateWithoutConfig(t *testing.T) {
+	app, _, out := newTestApp(t, input.NewFileCollector(nil))
+	got := run(t, app, out, ExitFailure, "update", target)
+	if !strings.Contains(got, "proser init") {
+		t.Errorf("update output does not point to init:\n%s", got)
+	}
+}
+
+func TestCheckExitCodes(t *testing.T) {
+	const instructions = ".github/instructions/backend.instructions.md"
+
+	tests := []struct {
+		name string
+		args []string
+		edit func(t *testing.T, fsys filesystem.FileSystem)
+		init bool
+		want int
+	}{
+		{
+			name: "up to date",
+			init: true,
+			want: ExitOK,
+		},
+		{
+			name: "text outside managed regions",
+			init: true,
+			edit: func(t *testing.T, fsys filesystem.FileSystem) {
+				writeFile(t, fsys, "AGENTS.md", readFile(t, fsys, "AGENTS.md")+"\n## Team Notes\n")
+			},
+			want: ExitOK,
+		},
+		{
+			name: "file added to the repository",
+			init: true,
+			edit: func(t *testing.T, fsys filesystem.FileSystem) {
+				writeFile(t, fsys, "main.go", "package main\n")
+				writeFile(t, fsys, "Makefile", "build:\n\tgo build ./...\n")
+			},
+			want: ExitOK,
+		},
+		{
+			name: "stale file",
+			init: true,
+			edit: func(t *testing.T, fsys filesystem.FileSystem) {
+				writeFile(t, fsys, instructions, "edited\n")
+			},
+			want: ExitStale,
+		},
+		{
+			name: "missing file",
+			init: true,
+			edit: func(t *testing.T, fsys filesystem.FileSystem) {
+				if err := fsys.Remove(filepath.Join(target, instructions)); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: ExitStale,
+		},
+		{
+			name: "unknown flag",
+			init: true,
+			args: []string{"--bogus"},
+			want: ExitUsage,
+		},
+		{
+			name: "no saved configuration",
+			want: ExitFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, fsys, out := newTestApp(t, input.NewFileCollector(nil))
+			if tt.init {
+				initBackend(t, app, out)
+			}
+			if tt.edit != nil {
+				tt.edit(t, fsys)
+			}
+			args := append([]string{"check"}, tt.args...)
+			run(t, app, out, tt.want, append(args, target)...)
+		})
+	}
+}
+
+func TestDiffExitCodes(t *testing.T) {
+	app, fsys, out := newTestApp(t, input.NewFileCollector(nil))
+	initBackend(t, app, out)
+
+	if got := run(t, app, out, ExitOK, "diff", target); got != "" {
+		t.Errorf("diff of an up-to-date project = %q, want no output", got)
+	}
+
+	const instructions = ".github/instructions/backend.instructions.md"
+	writeFile(t, fsys, instructions, readFile(t, fsys, instructions)+"extra\n")
+	got := run(t, app, out, ExitStale, "diff", target)
+	if !strings.HasPrefix(got, "--- a/"+instructions+"\n") || !strings.Contains(got, "\n-extra\n") {
+		t.Errorf("diff output is not a patch removing the extra line:\n%s", got)
+	}
+}
+
+func TestClean(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		edit     bool
+		want     int
+		wantKept bool
+	}{
+		{name: "untouched files", want: ExitOK},
+		{name: "modified file kept", edit: true, want: ExitStale, wantKept: true},
+		{name: "force", args: []string{"--force"}, edit: true, want: ExitOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, fsys, out := newTestApp(t, input.NewFileCollector(nil))
+			initBackend(t, app, out)
+			if tt.edit {
+				writeFile(t, fsys, "AGENTS.md", readFile(t, fsys, "AGENTS.md")+"\n## Team Notes\n")
+				run(t, app, out, ExitOK, "update", target)
+			}
+
+			args := append([]string{"clean"}, tt.args...)
+			got := run(t, app, out, tt.want, append(args, target)...)
+
+			if exists(fsys, "AGENTS.md") != tt.wantKept {
+				t.Errorf("AGENTS.md kept = %v, want %v; output:\n%s", !tt.wantKept, tt.wantKept, got)
+			}
+			if exists(fsys, ".github") {
+				t.Errorf(".github was not pruned; output:\n%s", got)
+			}
+			if exists(fsys, ".proser") != tt.wantKept {
+				t.Errorf(".proser kept = %v, want %v", !tt.wantKept, tt.wantKept)
+			}
+			// The configuration stays with the kept files, so update still works
+			if exists(fsys, config.FileName) != tt.wantKept {
+				t.Errorf("%s kept = %v, want %v", config.FileName, !tt.wantKept, tt.wantKept)
+			}
+			// The manifest stays while it tracks a kept file, so --force can remove it later
+			if exists(fsys, generator.ManifestPath) != tt.wantKept {
+				t.Errorf("manifest kept = %v, want %v", !tt.wantKept, tt.wantKept)
+			}
+		})
+	}
+}
+
+func TestCleanKeepsExistingDirs(t *testing.T) {
+	app, fsys, out := newTestApp(t, input.NewFileCollector(nil))
+	if err := fsys.MkdirAll(filepath.Join(target, ".github"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	initBackend(t, app, out)
+
+	got := run(t, app, out, ExitOK, "clean", target)
+	if exists(fsys, ".github/instructions") {
+		t.Errorf(".github/instructions was not pruned; output:\n%s", got)
+	}
+	if !exists(fsys, ".github") {
+		t.Errorf(".github existed before init but was removed; output:\n%s", got)
+	}
+}
+
+func TestUnknownCommand(t *testing.T) {
+	app, _, out := newTestApp(t, input.NewFileCollector(nil))
+	got := run(t, app, out, ExitUsage, "frobnicate")
+	if !strings.Contains(got, `unknown command "frobnicate"`) {
+		t.Errorf("output lacks the unknown command:\n%s", got)
+	}
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/mongoose84/proser/config"
+	"github.com/mongoose84/proser/generator"
+)
+
+// runCheck regenerates files in memory from the saved configuration and fails
+// when any file on disk is missing or out of date
+func (a *App) runCheck(flags *flag.FlagSet, args []string) error {
+	showDiff := flags.Bool("diff", false, "print a unified diff for each stale file")
+	absTarget, err := a.parseTarget(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := a.loadLanguages(absTarget); err != nil {
+		return err
+	}
+	a.printf("📁 Target directory: %s\n\n", absTarget)
+
+	results, err := a.checkFiles(absTarget)
+	if err != nil {
+		return err
+	}
+
+	stale := 0
+	for _, result := range results {
+		if !result.Stale() {
+			continue
+		}
+		stale++
+		switch result.Status {
+		case generator.StatusNew:
+			a.printf("  ✗ missing  %s (%s)\n", result.Path, result.Generator)
+		default:
+			a.printf("  ✗ stale    %s (%s)\n", result.Path, result.Generator)
+		}
+		if *showDiff {
+			a.println()
+			a.printf("%s", result.Diff())
+			a.println()
+		}
+	}
+
+	if stale > 0 {
+		a.printf("\n❌ %d of %d generated files are out of date. Run `proser update` to refresh them.\n", stale, len(results))
+		return errStale
+	}
+	a.printf("✅ All %d generated files are up to date.\n", len(results))
+	return nil
+}
+
+// runDiff prints a unified diff of every file update would change, and nothing else,
+// so the output can be piped into a patch tool
+func (a *App) runDiff(flags *flag.FlagSet, args []string) error {
+	absTarget, err := a.parseTarget(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := a.loadLanguages(absTarget); err != nil {
+		return err
+	}
+
+	results, err := a.checkFiles(absTarget)
+	if err != nil {
+		return err
+	}
+
+	stale := false
+	for _, result := range results {
+		if result.Stale() {
+			stale = true
+			a.printf("%s", result.Diff())
+		}
+	}
+	if stale {
+		return errStale
+	}
+	return nil
+}
+
+// checkFiles compares what the saved configuration generates with the files on disk
+func (a *App) checkFiles(absTarget string) ([]generator.CheckResult, error) {
+	projectType, answers, err := a.loadSavedConfig(absTarget)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	ctx := generator.GenerateContext{
+		Config:     config.FromAnswers(answers),
+		TargetPath: absTarget,
+		FS:         a.FS,
+		Languages:  a.Languages,
+	}
+	return generator.Check(projectType.Generators(), ctx)
+}
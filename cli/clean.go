@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/mongoose84/proser/config"
+	"github.com/mongoose84/proser/generator"
+)
+
+// runClean removes the files proser generated, keeping hand-modified ones unless forced
+func (a *App) runClean(flags *flag.FlagSet, args []string) error {
+	force := flags.Bool("force", false, "also remove files modified since generation")
+	keepConfig := flags.Bool("keep-config", false, "keep the saved "+config.FileName)
+	absTarget, err := a.parseTarget(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := a.loadLanguages(absTarget); err != nil {
+		return err
+	}
+	a.printf("📁 Target directory: %s\n\n", absTarget)
+
+	manifest, err := generator.LoadManifest(a.FS, absTarget)
+	if err != nil {
+		return err
+	}
+
+	// Without a manifest, fall back to the files the saved configuration generates
+	if len(manifest.Files) == 0 {
+		manifest, err = a.manifestFromConfig(absTarget)
+		if err != nil {
+			return err
+		}
+	}
+
+	results, err := generator.Clean(a.FS, absTarget, manifest, *force)
+	if err != nil {
+		return err
+	}
+
+	kept := 0
+	for _, result := range results {
+		switch result.Action {
+		case generator.CleanRemoved:
+			a.printf("  ✓ Removed %s\n", result.Path)
+		case generator.CleanKeptModified:
+			kept++
+			a.printf("  ✎ Kept %s (modified since generation)\n", result.Path)
+		}
+	}
+
+	// Keep the manifest while it still tracks files, so a later --force can remove them
+	pruned := map[string]bool{}
+	if len(manifest.Files) > 0 {
+		if err := manifest.Save(a.FS, absTarget); err != nil {
+			return fmt.Errorf("failed to save manifest: %w", err)
+		}
+	} else {
+		if err := a.removeIfExists(absTarget, generator.ManifestPath); err != nil {
+			return err
+		}
+		pruned[filepath.Dir(filepath.Join(absTarget, generator.ManifestPath))] = true
+	}
+	// Keep the configuration while modified files remain, so update and check still work
+	switch {
+	case *keepConfig:
+	case kept > 0:
+		a.printf("  ✎ Kept %s while modified files remain\n", config.FileName)
+	default:
+		if err := a.removeIfExists(absTarget, config.FileName); err != nil {
+			return err
+		}
+	}
+	if err := manifest.PruneDirs(a.FS, absTarget, pruned); err != nil {
+		return err
+	}
+
+	if kept > 0 {
+		a.printf("\n⚠️  %d modified file(s) kept. Re-run with --force to remove them too.\n", kept)
+		return errStale
+	}
+	a.println("\n✅ Clean complete!")
+	return nil
+}
+
+// manifestFromConfig builds a manifest from what the saved configuration generates today,
+// so files that still match that output count as unmodified
+func (a *App) manifestFromConfig(absTarget string) (*generator.Manifest, error) {
+	projectType, answers, err := a.loadSavedConfig(absTarget)
+	if err != nil {
+		return nil, fmt.Errorf("no manifest found and %w", err)
+	}
+
+	ctx := generator.GenerateContext{
+		Config:     config.FromAnswers(answers),
+		TargetPath: absTarget,
+		FS:         a.FS,
+		Languages:  a.Languages,
+	}
+	results, err := generator.Check(projectType.Generators(), ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := generator.NewManifest()
+	configHash := generator.HashConfig(ctx.Config)
+	for _, result := range results {
+		if result.Status != generator.StatusNew {
+			manifest.Record(result.Path, result.Generator, configHash, result.Generated, result.Content)
+		}
+	}
+	return manifest, nil
+}
+
+// removeIfExists deletes a file below the target, ignoring files that are already gone
+func (a *App) removeIfExists(absTarget, relPath string) error {
+	path := filepath.Join(absTarget, relPath)
+	if _, err := a.FS.Stat(path); err != nil {
+		return nil
+	}
+	if err := a.FS.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	a.printf("  ✓ Removed %s\n", relPath)
+	return nil
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mongoose84/proser/generator"
+	"github.com/mongoose84/proser/project"
+)
+
+// runOptions controls how generated files are written
+type runOptions struct {
+	dryRun            bool       // list files with their status without writing
+	diff              bool       // print a unified diff without writing
+	onConflict        string     // default conflict policy for existing files
+	backupDir         string     // timestamped backup directory for the backup policy
+	generatorPolicies stringList // per-generator conflict policies (generator=policy)
+}
+
+// stringList is a repeatable string flag
+type stringList []string
+
+func (l *stringList) String() string     { return strings.Join(*l, ",") }
+func (l *stringList) Set(v string) error { *l = append(*l, v); return nil }
+
+// preview reports whether files should be shown instead of written
+func (o *runOptions) preview() bool {
+	return o.dryRun || o.diff
+}
+
+// addWriteFlags registers the preview and conflict policy flags on a flag set
+func addWriteFlags(flags *flag.FlagSet) *runOptions {
+	opts := &runOptions{}
+	flags.BoolVar(&opts.dryRun, "dry-run", false, "list files and their status without writing")
+	flags.BoolVar(&opts.diff, "diff", false, "print a unified diff against existing files without writing")
+	flags.StringVar(&opts.onConflict, "on-conflict", string(generator.PolicyOverwrite), "what to do with existing files that differ: overwrite, skip, backup or prompt")
+	flags.StringVar(&opts.backupDir, "backup-dir", "", "directory for timestamped backups instead of *.bak files")
+	flags.Var(&opts.generatorPolicies, "generator-policy", "per-generator conflict policy (generator=policy, repeatable)")
+	return opts
+}
+
+// addQuestionFlags registers one string flag per question key, e.g. --backend-language
+func addQuestionFlags(flags *flag.FlagSet) map[string]*string {
+	values := make(map[string]*string)
+	for _, q := range project.AllQuestions() {
+		values[q.Key] = flags.String(questionFlagName(q.Key), "", fmt.Sprintf("%s [default: %s]", q.Prompt, q.DefaultValue))
+	}
+	return values
+}
+
+// setQuestionFlags returns the answers for question flags given on the command line
+func setQuestionFlags(flags *flag.FlagSet, values map[string]*string) map[string]string {
+	preset := make(map[string]string)
+	flags.Visit(func(f *flag.Flag) {
+		for key, value := range values {
+			if questionFlagName(key) == f.Name {
+				preset[key] = *value
+			}
+		}
+	})
+	return preset
+}
+
+// questionFlagName converts a question key into its flag name
+func questionFlagName(key string) string {
+	return strings.ReplaceAll(key, "_", "-")
+}
+
+// parseArgs parses flags for commands without positional arguments
+func parseArgs(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return parseError(err)
+	}
+	if flags.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+	return nil
+}
+
+// parseTarget parses flags and returns the absolute target directory
+func (a *App) parseTarget(flags *flag.FlagSet, args []string) (string, error) {
+	if err := flags.Parse(args); err != nil {
+		return "", parseError(err)
+	}
+	if flags.NArg() > 1 {
+		return "", usageErrorf("unexpected arguments: %s", strings.Join(flags.Args()[1:], " "))
+	}
+
+	// Parse target path argument
+	targetPath := "."
+	if flags.NArg() > 0 {
+		targetPath = flags.Arg(0)
+	}
+
+	// Resolve to absolute path
+	absTarget, err := filepath.Abs(targetPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve target path: %w", err)
+	}
+
+	// Verify target path exists and is a directory
+	fileInfo, err := a.FS.Stat(absTarget)
+	if err != nil {
+		return "", fmt.Errorf("failed to access target path: %w", err)
+	}
+	if !fileInfo.IsDir() {
+		return "", fmt.Errorf("target path is not a directory: %s", absTarget)
+	}
+	return absTarget, nil
+}
+
+// parseError converts a flag parsing error; the flag package has already reported it
+func parseError(err error) error {
+	if err == flag.ErrHelp {
+		return err
+	}
+	return &exitError{code: ExitUsage}
+}
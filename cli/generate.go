@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/mongoose84/proser/config"
+	"github.com/mongoose84/proser/generator"
+	"github.com/mongoose84/proser/input"
+	"github.com/mongoose84/proser/project"
+)
+
+// newWriter creates a writer configured with the conflict policies from the options.
+// The prompt policy needs someone to answer, so it is refused when interactive is false.
+func (a *App) newWriter(collector input.InputCollector, interactive bool, projectType project.ProjectType, opts *runOptions) (*generator.Writer, error) {
+	policy, err := generator.ParseConflictPolicy(opts.onConflict)
+	if err != nil {
+		return nil, err
+	}
+	generatorPolicies, err := generator.ParseGeneratorPolicies(opts.generatorPolicies)
+	if err != nil {
+		return nil, err
+	}
+	if err := generator.ValidateGeneratorNames(generatorPolicies, projectType.Generators()); err != nil {
+		return nil, err
+	}
+	if !interactive {
+		policies := []generator.ConflictPolicy{policy}
+		for _, p := range generatorPolicies {
+			policies = append(policies, p)
+		}
+		for _, p := range policies {
+			if p == generator.PolicyPrompt {
+				return nil, fmt.Errorf("conflict policy %q needs interactive input; use overwrite, skip or backup with --yes or --config", p)
+			}
+		}
+	}
+
+	writer := generator.NewWriter(a.FS)
+	writer.Policy = policy
+	writer.GeneratorPolicies = generatorPolicies
+	writer.BackupDir = opts.backupDir
+	writer.Prompter = collector
+	return writer, nil
+}
+
+// generateFiles runs every generator for the project type and writes or previews its output
+func (a *App) generateFiles(writer *generator.Writer, projectType project.ProjectType, cfg config.ProjectConfig, absTarget string, opts *runOptions) error {
+	// Create generation context
+	ctx := generator.GenerateContext{
+		Config:     cfg,
+		TargetPath: absTarget,
+		FS:         a.FS,
+		Languages:  a.Languages,
+	}
+
+	// Report what happened to previously generated files since the last run
+	manifest, err := generator.LoadManifest(a.FS, absTarget)
+	if err != nil {
+		return err
+	}
+	if err := a.reportDrift(manifest, absTarget, generator.HashConfig(cfg)); err != nil {
+		return err
+	}
+	writer.Manifest = manifest
+
+	// Run all generators for this project type
+	produced := make(map[string]bool)
+	for _, gen := range projectType.Generators() {
+		if opts.preview() {
+			if err := a.previewGenerator(writer, gen, ctx, opts); err != nil {
+				return err
+			}
+			continue
+		}
+		results, err := writer.RunGenerator(gen, ctx)
+		if err != nil {
+			return err
+		}
+		a.printf("  ✓ Generated %s files\n", gen.Name())
+		for _, result := range results {
+			produced[filepath.ToSlash(result.Path)] = true
+			switch result.Action {
+			case generator.ActionUpdated:
+				a.printf("    ✎ Updated %s\n", result.Path)
+			case generator.ActionSkipped:
+				a.printf("    ↷ Kept existing %s\n", result.Path)
+			case generator.ActionBackedUp:
+				a.printf("    ↺ Backed up %s to %s\n", result.Path, result.BackupPath)
+			}
+		}
+	}
+
+	if opts.preview() {
+		return nil
+	}
+
+	// Files generated by earlier runs that no generator produces anymore are left in place
+	for _, relPath := range manifest.Retain(produced) {
+		a.printf("  ⚠️  %s is no longer generated; remove it if it is not needed\n", relPath)
+	}
+	if err := manifest.Save(a.FS, absTarget); err != nil {
+		return fmt.Errorf("failed to save manifest: %w", err)
+	}
+	return nil
+}
+
+// reportDrift lists generated files that were edited or deleted since proser wrote them,
+// or were generated from a configuration other than the one with configHash
+func (a *App) reportDrift(manifest *generator.Manifest, absTarget, configHash string) error {
+	drift, err := manifest.Drift(a.FS, absTarget, configHash)
+	if err != nil {
+		return fmt.Errorf("failed to compare with manifest: %w", err)
+	}
+	if len(drift) == 0 {
+		return nil
+	}
+
+	a.println("\n🔎 Changes since the last generation:")
+	for _, d := range drift {
+		switch d.Status {
+		case generator.DriftModified:
+			a.printf("  ✎ %s was modified by hand\n", d.Path)
+		case generator.DriftDeleted:
+			a.printf("  ✗ %s was deleted\n", d.Path)
+		case generator.DriftConfigChanged:
+			a.printf("  ⚙ %s was generated from a different configuration\n", d.Path)
+		}
+	}
+	a.println()
+	return nil
+}
+
+// previewGenerator prints the status of each file a generator would write, plus diffs if requested
+func (a *App) previewGenerator(writer *generator.Writer, gen generator.Generator, ctx generator.GenerateContext, opts *runOptions) error {
+	planned, err := writer.PreviewGenerator(gen, ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, pf := range planned {
+		a.printf("  %-10s %s\n", pf.Status, pf.Path)
+		if opts.diff && pf.Status != generator.StatusUnchanged {
+			a.println()
+			a.printf("%s", pf.Diff())
+			a.println()
+		}
+	}
+	return nil
+}
+
+// loadSavedConfig reads .proser.yaml from the target and resolves its project type
+func (a *App) loadSavedConfig(absTarget string) (project.ProjectType, map[string]string, error) {
+	typeName, answers, err := config.Load(a.FS, absTarget)
+	if err != nil {
+		return nil, nil, err
+	}
+	projectType, exists := project.Get(typeName)
+	if !exists {
+		return nil, nil, fmt.Errorf("unknown project type '%s' in %s", typeName, config.FileName)
+	}
+	return projectType, answers, nil
+}
@@ -0,0 +1,355 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/mongoose84/proser/config"
+	"github.com/mongoose84/proser/detect"
+	"github.com/mongoose84/proser/input"
+	"github.com/mongoose84/proser/project"
+)
+
+// runInit collects answers, saves the resolved configuration and generates all files
+func (a *App) runInit(flags *flag.FlagSet, args []string) error {
+	configPath := flags.String("config", "", "answers file (YAML or JSON) to use instead of prompting")
+	yes := flags.Bool("yes", false, "accept defaults for every question not answered by flags")
+	noDetect := flags.Bool("no-detect", false, "do not scan the target for languages to use as defaults")
+	opts := addWriteFlags(flags)
+	questionFlags := addQuestionFlags(flags)
+
+	a.printBanner()
+	absTarget, err := a.parseTarget(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := a.loadLanguages(absTarget); err != nil {
+		return err
+	}
+	a.printf("📁 Target directory: %s\n\n", absTarget)
+
+	// Answers given as flags are never asked again
+	preset := setQuestionFlags(flags, questionFlags)
+
+	var collector input.InputCollector = input.NewPresetCollector(preset, a.In)
+	if *yes {
+		collector = input.NewFileCollector(preset)
+	}
+
+	// Answers file replaces the input collector for every question, with flags taking precedence.
+	// A file that cannot be used is a bad argument, like an unknown flag.
+	if *configPath != "" {
+		fileAnswers, err := input.LoadAnswersFile(a.FS, *configPath)
+		if err != nil {
+			return usageErrorf("failed to load answers: %v", err)
+		}
+		fileCollector := input.NewFileCollector(fileAnswers)
+		if err := fileCollector.Validate(project.AllQuestions()); err != nil {
+			return usageErrorf("invalid answers file %s: %v", *configPath, err)
+		}
+		for k, v := range preset {
+			fileAnswers[k] = v
+		}
+		collector = fileCollector
+		a.printf("📄 Using answers from %s\n\n", *configPath)
+	}
+
+	// Values detected in the target replace the built-in question defaults
+	defaults := map[string]string{}
+	if !*noDetect {
+		detected, err := detect.Detect(a.FS, absTarget, a.Languages)
+		if err != nil {
+			return err
+		}
+		defaults = detected.Answers()
+		a.displayDetected(defaults)
+	}
+
+	// Let user pick project type
+	_, typePreset := preset[project.TypeQuestion().Key]
+	projectType, err := a.selectProjectType(collector, *configPath == "" && !*yes && !typePreset, defaults)
+	if err != nil {
+		return err
+	}
+
+	answers, err := a.collectAnswers(collector, projectType, *configPath != "", defaults)
+	if err != nil {
+		return fmt.Errorf("failed to collect input: %w", err)
+	}
+
+	// Flags win over auto-filled defaults for every key the project type uses
+	for k, v := range preset {
+		if _, exists := answers[k]; exists {
+			answers[k] = v
+		}
+	}
+
+	// Build config from answers
+	cfg := config.FromAnswers(answers)
+
+	// Display configuration summary
+	a.displaySummary(cfg)
+
+	writer, err := a.newWriter(collector, *configPath == "" && !*yes, projectType, opts)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	if opts.preview() {
+		a.println("\n🔍 Previewing files based on your configuration...")
+		if err := a.generateFiles(writer, projectType, cfg, absTarget, opts); err != nil {
+			return err
+		}
+		a.println("\n🔍 Preview complete — no files were written.")
+		return nil
+	}
+
+	// Persist the resolved answers so `proser update` can regenerate without prompting
+	if err := config.Save(a.FS, absTarget, projectType.Name(), answers); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	a.println("\n📝 Generating files based on your configuration...")
+	if err := a.generateFiles(writer, projectType, cfg, absTarget, opts); err != nil {
+		return err
+	}
+
+	a.println("\n✅ Setup complete!")
+	a.println("📁 Files created in .github/")
+	a.println("📄 AGENTS.md created at project root")
+	a.printf("💾 Configuration saved to %s\n", config.FileName)
+	a.println("\n🎉 Your project is now configured for PROSE Architectural Style for AI-Native Development!")
+	a.println("💡 Ask your AI agent to expand AGENTS.md with project-specific details.")
+	a.println("   Content outside the proser:begin/proser:end markers is kept by `proser update`.")
+	return nil
+}
+
+// selectProjectType prompts the user to select a project type. An unknown type falls back
+// to fullstack when asked interactively; from flags or an answers file it is a usage error,
+// so a typo in a CI configuration does not generate the wrong files.
+func (a *App) selectProjectType(collector input.InputCollector, showMenu bool, defaults map[string]string) (project.ProjectType, error) {
+	if showMenu {
+		a.println("Select project type:")
+		for i, pt := range project.GetAll() {
+			a.printf("  %d. %s - %s\n", i+1, pt.Name(), pt.Description())
+		}
+		a.println()
+	}
+
+	// For now, just ask for a selection
+	typeQuestion := project.TypeQuestion()
+	answers, err := collector.Collect(withDefaults([]input.Question{typeQuestion}, defaults))
+	if err != nil || answers[typeQuestion.Key] == "" {
+		// Default to fullstack
+		pt, _ := project.Get("fullstack")
+		return pt, nil
+	}
+
+	// Get the selected project type
+	pt, exists := project.Get(answers[typeQuestion.Key])
+	if !exists {
+		if !showMenu {
+			return nil, usageErrorf("unknown project type %q (want fullstack, frontend or backend)", answers[typeQuestion.Key])
+		}
+		a.printf("⚠️  Unknown project type '%s', using fullstack\n", answers[typeQuestion.Key])
+		pt, _ = project.Get("fullstack")
+	}
+
+	return pt, nil
+}
+
+// collectAnswers gathers the answers for a project type. Answers files skip the
+// quick/custom choice and answer every detailed question, falling back to defaults.
+func (a *App) collectAnswers(collector input.InputCollector, projectType project.ProjectType, fromFile bool, defaults map[string]string) (map[string]string, error) {
+	if fromFile {
+		return collector.Collect(withDefaults(projectType.Questions(), defaults))
+	}
+
+	// Ask if user wants to generate all files
+	a.println("\n🤖 Quick setup or custom configuration?")
+	a.println()
+
+	earlyAnswers, err := collector.Collect([]input.Question{
+		{Key: "generate_all_files", Prompt: "Generate all recommended files for this project type? (yes/no)", DefaultValue: "yes"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user wants all files or custom selection
+	generateAll := earlyAnswers["generate_all_files"]
+	if generateAll == "yes" || generateAll == "y" || generateAll == "Y" || generateAll == "YES" {
+		// Quick setup: collect only essential questions
+		return a.collectQuickSetup(collector, projectType, defaults)
+	}
+
+	// Custom setup: collect all detailed questions
+	a.println("\nPlease answer the following questions about your project:")
+	a.println()
+	return collector.Collect(a.withLanguageChecks(withDefaults(projectType.Questions(), defaults)))
+}
+
+// collectQuickSetup collects only essential questions and auto-enables all appropriate files
+func (a *App) collectQuickSetup(collector input.InputCollector, projectType project.ProjectType, defaults map[string]string) (map[string]string, error) {
+	a.println("\n⚡ Quick setup mode - collecting essential project information:")
+	a.println()
+
+	// Determine which questions to ask based on project type
+	var questions []input.Question
+
+	// Always ask general questions
+	questions = append(questions, input.Question{Key: "project_name", Prompt: "Project name", DefaultValue: "my-project"})
+	questions = append(questions, input.Question{Key: "description", Prompt: "Project description", DefaultValue: "A software project"})
+	questions = append(questions, input.Question{Key: "code_style", Prompt: "Code style guidelines", DefaultValue: "Follow standard formatting"})
+	questions = append(questions, input.Question{Key: "security", Prompt: "Security requirements", DefaultValue: "Follow OWASP top 10"})
+
+	// Ask tech stack questions based on project type
+	switch projectType.Name() {
+	case "fullstack":
+		questions = append(questions, input.Question{Key: "frontend_language", Prompt: "Frontend language", DefaultValue: "JavaScript"})
+		questions = append(questions, input.Question{Key: "frontend_framework", Prompt: "Frontend framework", DefaultValue: "React"})
+		questions = append(questions, input.Question{Key: "backend_language", Prompt: "Backend language", DefaultValue: "Go"})
+		questions = append(questions, input.Question{Key: "backend_framework", Prompt: "Backend framework", DefaultValue: "None"})
+		questions = append(questions, input.Question{Key: "backend_database", Prompt: "Database", DefaultValue: "PostgreSQL"})
+		questions = append(questions, input.Question{Key: "testing_framework", Prompt: "Testing framework", DefaultValue: "Jest"})
+
+	case "frontend":
+		questions = append(questions, input.Question{Key: "frontend_language", Prompt: "Frontend language", DefaultValue: "JavaScript"})
+		questions = append(questions, input.Question{Key: "frontend_framework", Prompt: "Frontend framework", DefaultValue: "React"})
+		questions = append(questions, input.Question{Key: "frontend_build_tool", Prompt: "Build tool", DefaultValue: "Vite"})
+		questions = append(questions, input.Question{Key: "testing_framework", Prompt: "Testing framework", DefaultValue: "Jest"})
+
+	case "backend":
+		questions = append(questions, input.Question{Key: "backend_language", Prompt: "Backend language", DefaultValue: "Go"})
+		questions = append(questions, input.Question{Key: "backend_framework", Prompt: "Backend framework", DefaultValue: "None"})
+		questions = append(questions, input.Question{Key: "backend_database", Prompt: "Database", DefaultValue: "PostgreSQL"})
+		questions = append(questions, input.Question{Key: "testing_framework", Prompt: "Testing framework", DefaultValue: "Go testing"})
+	}
+
+	// Collect answers
+	answers, err := collector.Collect(a.withLanguageChecks(withDefaults(questions, defaults)))
+	if err != nil {
+		return nil, err
+	}
+
+	// Auto-populate remaining answers for all files
+	allAnswers := config.DefaultAnswersForProjectType(projectType.Name(), answers)
+
+	a.println()
+	a.println("✨ Enabling all recommended files:")
+	a.println("  • Core instructions (copilot-instructions, domain-specific)")
+	a.println("  • Agent definitions (architect, engineers, code reviewer, etc.)")
+	a.println("  • Prompt templates (code review, feature spec, refactor, bug fix)")
+	a.println("  • Specification templates (feature, API, component)")
+	a.println("  • AGENTS.md discovery file")
+
+	return allAnswers, nil
+}
+
+// withDefaults returns a copy of questions with defaults replaced by the given values
+func withDefaults(questions []input.Question, defaults map[string]string) []input.Question {
+	result := make([]input.Question, len(questions))
+	for i, q := range questions {
+		if value, ok := defaults[q.Key]; ok {
+			q.DefaultValue = value
+		}
+		result[i] = q
+	}
+	return result
+}
+
+// withLanguageChecks returns a copy of questions whose language answers are checked
+// against the registry, so typos are caught before generic guidelines are generated
+func (a *App) withLanguageChecks(questions []input.Question) []input.Question {
+	result := make([]input.Question, len(questions))
+	for i, q := range questions {
+		if q.Key == "frontend_language" || q.Key == "backend_language" {
+			q.Validate = a.checkLanguage
+		}
+		result[i] = q
+	}
+	return result
+}
+
+// checkLanguage reports a language the registry does not know, with the closest matches
+func (a *App) checkLanguage(answer string) error {
+	if strings.EqualFold(answer, "none") {
+		return nil
+	}
+	if _, ok := a.Languages.LookupLanguage(answer); ok {
+		return nil
+	}
+	suggestions := a.Languages.SuggestLanguages(answer)
+	if len(suggestions) == 0 {
+		return fmt.Errorf("unknown language '%s' — generic guidelines will be generated for it", answer)
+	}
+	return fmt.Errorf("unknown language '%s' — did you mean %s?", answer, joinOr(suggestions))
+}
+
+// joinOr joins names as "A", "A or B" or "A, B or C"
+func joinOr(names []string) string {
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+// displayDetected lists the values detected in the target directory
+func (a *App) displayDetected(defaults map[string]string) {
+	if len(defaults) == 0 {
+		return
+	}
+
+	a.println("🔍 Detected from existing files:")
+	for _, q := range project.AllQuestions() {
+		if value, ok := defaults[q.Key]; ok {
+			a.printf("  %s: %s\n", q.Key, value)
+		}
+	}
+	a.println()
+}
+
+// displaySummary shows the configuration summary
+func (a *App) displaySummary(cfg config.ProjectConfig) {
+	a.println("\n📋 Configuration Summary:")
+	a.printf("  Project: %s\n", cfg.General.ProjectName)
+	if cfg.General.Description != "" {
+		a.printf("  Description: %s\n", cfg.General.Description)
+	}
+	if cfg.HasFrontend() {
+		a.printf("  Frontend: %s", cfg.Frontend.Language)
+		if cfg.Frontend.Framework != "" {
+			a.printf(" with %s", cfg.Frontend.Framework)
+		}
+		if cfg.Frontend.BuildTool != "" {
+			a.printf(" (%s)", cfg.Frontend.BuildTool)
+		}
+		a.println()
+	}
+	if cfg.HasBackend() {
+		a.printf("  Backend: %s", cfg.Backend.Language)
+		if cfg.Backend.Framework != "" && cfg.Backend.Framework != "None" {
+			a.printf(" with %s", cfg.Backend.Framework)
+		}
+		if cfg.Backend.Database != "" {
+			a.printf(" + %s", cfg.Backend.Database)
+		}
+		a.println()
+	}
+	if cfg.Testing.Framework != "" {
+		a.printf("  Testing: %s (%s)\n", cfg.Testing.Framework, cfg.Testing.Strategy)
+	}
+	if cfg.General.CodeStyle != "" {
+		a.printf("  Code Style: %s\n", cfg.General.CodeStyle)
+	}
+	if cfg.HasBackend() && cfg.Backend.APIRules != "" {
+		a.printf("  API Rules: %s\n", cfg.Backend.APIRules)
+	}
+	if cfg.General.Security != "" {
+		a.printf("  Security: %s\n", cfg.General.Security)
+	}
+	if cfg.General.CustomRules != "" && cfg.General.CustomRules != "None" {
+		a.printf("  Custom Rules: %s\n", cfg.General.CustomRules)
+	}
+}
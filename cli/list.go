@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"flag"
+	"runtime/debug"
+	"strings"
+
+	"github.com/mongoose84/proser/language"
+	"github.com/mongoose84/proser/project"
+)
+
+// Version is the proser release, set at build time with
+// -ldflags "-X github.com/mongoose84/proser/cli.Version=v1.2.3"
+var Version = "dev"
+
+// runList prints every project type with the generators it runs
+func (a *App) runList(flags *flag.FlagSet, args []string) error {
+	if err := parseArgs(flags, args); err != nil {
+		return err
+	}
+
+	a.println("Project types:")
+	for _, pt := range project.GetAll() {
+		names := make([]string, 0, len(pt.Generators()))
+		for _, gen := range pt.Generators() {
+			names = append(names, gen.Name())
+		}
+		a.printf("  %-10s %s\n", pt.Name(), pt.Description())
+		a.printf("  %-10s generators: %s\n", "", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// runSchema prints the JSON Schema of language definition files, for editors and CI.
+// proser checks the same rules in Go when it loads definitions.
+func (a *App) runSchema(flags *flag.FlagSet, args []string) error {
+	if err := parseArgs(flags, args); err != nil {
+		return err
+	}
+	_, err := a.Out.Write(language.Schema())
+	return err
+}
+
+// runVersion prints the proser version
+func (a *App) runVersion(flags *flag.FlagSet, args []string) error {
+	if err := parseArgs(flags, args); err != nil {
+		return err
+	}
+	a.printf("proser %s\n", version())
+	return nil
+}
+
+// version returns Version, falling back to the module version for `go install` builds
+func version() string {
+	if Version != "dev" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return Version
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mongoose84/proser/config"
+)
+
+// runUpdate reloads the saved configuration and re-runs the generators without prompting
+func (a *App) runUpdate(flags *flag.FlagSet, args []string) error {
+	opts := addWriteFlags(flags)
+
+	a.printBanner()
+	absTarget, err := a.parseTarget(flags, args)
+	if err != nil {
+		return err
+	}
+	if err := a.loadLanguages(absTarget); err != nil {
+		return err
+	}
+	a.printf("📁 Target directory: %s\n\n", absTarget)
+
+	projectType, answers, err := a.loadSavedConfig(absTarget)
+	if errors.Is(err, config.ErrNoSavedConfig) {
+		return fmt.Errorf("%w; run `proser init` in this directory first to create it", err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	cfg := config.FromAnswers(answers)
+	a.displaySummary(cfg)
+
+	writer, err := a.newWriter(a.In, true, projectType, opts)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+
+	if opts.preview() {
+		a.println("\n🔍 Previewing files from saved configuration...")
+		if err := a.generateFiles(writer, projectType, cfg, absTarget, opts); err != nil {
+			return err
+		}
+		a.println("\n🔍 Preview complete — no files were written.")
+		return nil
+	}
+
+	a.println("\n📝 Regenerating files from saved configuration...")
+	if err := a.generateFiles(writer, projectType, cfg, absTarget, opts); err != nil {
+		return err
+	}
+
+	a.println("\n✅ Update complete!")
+	return nil
+}
@@ -30,6 +30,14 @@ type TestingConfig struct {
 	Strategy  string // Unit, Integration, E2E focus
 }
 
+// StructureConfig holds repository structure configuration
+type StructureConfig struct {
+	TreeDepth     int      // directory levels shown in the AGENTS.md repository tree
+	NestedAgents  bool     // write scoped AGENTS.md files in module directories
+	NestedDepth   int      // deepest directory level that gets a scoped AGENTS.md
+	NestedExclude []string // globs of directories that never get a scoped AGENTS.md
+}
+
 // AgentsConfig holds agent configuration
 type AgentsConfig struct {
 	EnableArchitect       bool
@@ -59,13 +67,14 @@ type SpecsConfig struct {
 
 // ProjectConfig is the main configuration structure
 type ProjectConfig struct {
-	General  GeneralConfig
-	Frontend *FrontendConfig // nil if no frontend
-	Backend  *BackendConfig  // nil if no backend
-	Testing  TestingConfig
-	Agents   *AgentsConfig  // nil if no agents
-	Prompts  *PromptsConfig // nil if no prompts
-	Specs    *SpecsConfig   // nil if no specs
+	General   GeneralConfig
+	Frontend  *FrontendConfig // nil if no frontend
+	Backend   *BackendConfig  // nil if no backend
+	Testing   TestingConfig
+	Structure StructureConfig
+	Agents    *AgentsConfig  // nil if no agents
+	Prompts   *PromptsConfig // nil if no prompts
+	Specs     *SpecsConfig   // nil if no specs
 }
 
 // HasFrontend returns true if the project has frontend configuration
@@ -19,6 +19,18 @@ func DefaultAnswersForProjectType(projectType string, generalAnswers map[string]
 	if answers["testing_strategy"] == "" {
 		answers["testing_strategy"] = "Unit and Integration tests"
 	}
+	if answers["tree_depth"] == "" {
+		answers["tree_depth"] = "2"
+	}
+	if answers["nested_agents"] == "" {
+		answers["nested_agents"] = "no"
+	}
+	if answers["nested_agents_depth"] == "" {
+		answers["nested_agents_depth"] = "3"
+	}
+	if answers["nested_agents_exclude"] == "" {
+		answers["nested_agents_exclude"] = "None"
+	}
 	if answers["frontend_build_tool"] == "" {
 		answers["frontend_build_tool"] = "Vite"
 	}
@@ -1,6 +1,17 @@
 package config
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultTreeDepth is the repository tree depth used when tree_depth is missing or invalid
+	DefaultTreeDepth = 2
+
+	// DefaultNestedAgentsDepth is the nested AGENTS.md depth used when nested_agents_depth is missing or invalid
+	DefaultNestedAgentsDepth = 3
+)
 
 // FromAnswers creates a ProjectConfig from user input answers
 func FromAnswers(answers map[string]string) ProjectConfig {
@@ -16,6 +27,12 @@ func FromAnswers(answers map[string]string) ProjectConfig {
 			Framework: answers["testing_framework"],
 			Strategy:  answers["testing_strategy"],
 		},
+		Structure: StructureConfig{
+			TreeDepth:     positiveInt(answers["tree_depth"], DefaultTreeDepth),
+			NestedAgents:  shouldEnable(answers["nested_agents"]),
+			NestedDepth:   positiveInt(answers["nested_agents_depth"], DefaultNestedAgentsDepth),
+			NestedExclude: splitList(answers["nested_agents_exclude"]),
+		},
 	}
 
 	// Frontend config (only if not skipped)
@@ -75,6 +92,27 @@ func FromAnswers(answers map[string]string) ProjectConfig {
 	return cfg
 }
 
+// positiveInt parses a numeric answer, falling back to def when it is not a positive number
+func positiveInt(answer string, def int) int {
+	n, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || n < 1 {
+		return def
+	}
+	return n
+}
+
+// splitList splits a comma-separated answer into trimmed items; "None" yields no items
+func splitList(answer string) []string {
+	var items []string
+	for _, item := range strings.Split(answer, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" && strings.ToLower(item) != "none" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // shouldEnable returns true if the answer is affirmative
 func shouldEnable(answer string) bool {
 	lower := strings.ToLower(strings.TrimSpace(answer))
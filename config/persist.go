@@ -0,0 +1,70 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/mongoose84/proser/filesystem"
+	"github.com/mongoose84/proser/input"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the saved configuration file written to the target directory
+const FileName = ".proser.yaml"
+
+// projectTypeKey is the answer key holding the project type in the saved file
+const projectTypeKey = "project_type"
+
+// savedHeader is written at the top of the saved configuration file
+const savedHeader = "# Generated by proser. Re-run `proser update` after editing to refresh PROSE files.\n"
+
+// ErrNoSavedConfig is returned by Load when the target has no saved configuration
+var ErrNoSavedConfig = errors.New("no saved configuration found")
+
+// Save writes the resolved answers for a project type to .proser.yaml in the target directory.
+// The file uses the same flat key format as an answers file, so it can also be passed to --config.
+func Save(fsys filesystem.FileSystem, targetPath, projectType string, answers map[string]string) error {
+	saved := make(map[string]string, len(answers)+1)
+	for k, v := range answers {
+		saved[k] = v
+	}
+	saved[projectTypeKey] = projectType
+
+	data, err := yaml.Marshal(saved)
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+
+	path := filepath.Join(targetPath, FileName)
+	if err := fsys.WriteFile(path, append([]byte(savedHeader), data...), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Load reads .proser.yaml from the target directory and returns the project type and answers
+func Load(fsys filesystem.FileSystem, targetPath string) (string, map[string]string, error) {
+	path := filepath.Join(targetPath, FileName)
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", nil, fmt.Errorf("%w in %s", ErrNoSavedConfig, targetPath)
+		}
+		return "", nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	answers, err := input.ParseAnswers(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	projectType := answers[projectTypeKey]
+	if projectType == "" {
+		return "", nil, fmt.Errorf("%s does not specify %s", path, projectTypeKey)
+	}
+	delete(answers, projectTypeKey)
+
+	return projectType, answers, nil
+}
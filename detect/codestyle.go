@@ -0,0 +1,326 @@
+package detect
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// styleSource turns one linter or formatter configuration into a code style rule
+type styleSource struct {
+	files []string                          // paths relative to the root, may be globs
+	rule  func(name, content string) string // returns "" when the file yields nothing
+}
+
+// styleSources are checked in order; each contributes at most one rule
+var styleSources = []styleSource{
+	{[]string{".editorconfig"}, editorConfigStyle},
+	{[]string{".prettierrc", ".prettierrc.*", "prettier.config.*"}, prettierStyle},
+	{[]string{".eslintrc", ".eslintrc.*", "eslint.config.*"}, eslintStyle},
+	{[]string{".golangci.yml", ".golangci.yaml", ".golangci.json", ".golangci.toml"}, golangciStyle},
+	{[]string{"ruff.toml", ".ruff.toml", "pyproject.toml"}, ruffStyle},
+	{[]string{".rubocop.yml"}, rubocopStyle},
+	{[]string{"rustfmt.toml", ".rustfmt.toml"}, rustfmtStyle},
+	{[]string{"checkstyle.xml", "config/checkstyle.xml", "config/checkstyle/checkstyle.xml"}, checkstyleStyle},
+}
+
+// detectCodeStyle synthesizes code style rules from the linter and formatter
+// configurations in the project, joined with "; "
+func detectCodeStyle(s *scan) string {
+	var rules []string
+	for _, source := range styleSources {
+		for _, rel := range s.files {
+			if !matchesAny(rel, source.files) {
+				continue
+			}
+			content, ok := s.read(rel)
+			if !ok {
+				continue
+			}
+			if rule := source.rule(path.Base(rel), content); rule != "" {
+				rules = append(rules, rule)
+				break
+			}
+		}
+	}
+	return strings.Join(rules, "; ")
+}
+
+// editorConfigStyle reads the [*] section of an .editorconfig file
+func editorConfigStyle(_, content string) string {
+	settings := make(map[string]string)
+	section := ""
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			section = line[1 : len(line)-1]
+		case section == "*":
+			if key, value, ok := strings.Cut(line, "="); ok {
+				settings[strings.ToLower(strings.TrimSpace(key))] = strings.ToLower(strings.TrimSpace(value))
+			}
+		}
+	}
+
+	var parts []string
+	switch settings["indent_style"] {
+	case "tab":
+		parts = append(parts, "indent with tabs")
+	case "space":
+		if size := settings["indent_size"]; size != "" && size != "tab" {
+			parts = append(parts, fmt.Sprintf("indent with %s spaces", size))
+		} else {
+			parts = append(parts, "indent with spaces")
+		}
+	}
+	if width := settings["max_line_length"]; width != "" && width != "off" {
+		parts = append(parts, "max line length "+width)
+	}
+	if eol := settings["end_of_line"]; eol != "" {
+		parts = append(parts, strings.ToUpper(eol)+" line endings")
+	}
+	if settings["trim_trailing_whitespace"] == "true" {
+		parts = append(parts, "trim trailing whitespace")
+	}
+	if settings["insert_final_newline"] == "true" {
+		parts = append(parts, "end files with a newline")
+	}
+	return styleRule("EditorConfig", "", parts)
+}
+
+// prettierStyle reads the options of a JSON or YAML Prettier configuration
+func prettierStyle(name, content string) string {
+	var parts []string
+	if config, ok := decodeConfig(name, content); ok {
+		if lookup(config, "useTabs") == true {
+			parts = append(parts, "indent with tabs")
+		} else if width := lookup(config, "tabWidth"); width != nil {
+			parts = append(parts, fmt.Sprintf("%v-space indent", width))
+		}
+		if width := lookup(config, "printWidth"); width != nil {
+			parts = append(parts, fmt.Sprintf("print width %v", width))
+		}
+		switch lookup(config, "singleQuote") {
+		case true:
+			parts = append(parts, "single quotes")
+		case false:
+			parts = append(parts, "double quotes")
+		}
+		if lookup(config, "semi") == false {
+			parts = append(parts, "no semicolons")
+		}
+		if commas, ok := lookup(config, "trailingComma").(string); ok {
+			parts = append(parts, "trailing commas: "+commas)
+		}
+	}
+	return styleRule("Prettier", "npx prettier --write .", parts)
+}
+
+// eslintStyle lists the shared configurations a legacy .eslintrc extends
+func eslintStyle(name, content string) string {
+	var parts []string
+	if config, ok := decodeConfig(name, content); ok {
+		if extends := stringList(lookup(config, "extends")); len(extends) > 0 {
+			parts = append(parts, "extends "+strings.Join(extends, ", "))
+		}
+	}
+	return styleRule("ESLint", "npx eslint .", parts)
+}
+
+// golangciStyle lists the linters and formatters enabled in a golangci-lint configuration
+func golangciStyle(name, content string) string {
+	var parts []string
+	if config, ok := decodeConfig(name, content); ok {
+		if linters := stringList(lookup(config, "linters", "enable")); len(linters) > 0 {
+			parts = append(parts, "linters "+strings.Join(linters, ", "))
+		}
+		if formatters := stringList(lookup(config, "formatters", "enable")); len(formatters) > 0 {
+			parts = append(parts, "formatters "+strings.Join(formatters, ", "))
+		}
+		// golangci-lint v2 moved linter settings below linters
+		width := lookup(config, "linters", "settings", "lll", "line-length")
+		if width == nil {
+			width = lookup(config, "linters-settings", "lll", "line-length")
+		}
+		if width != nil {
+			parts = append(parts, fmt.Sprintf("max line length %v", width))
+		}
+	}
+	return styleRule("golangci-lint", "golangci-lint run", parts)
+}
+
+// ruffStyle reads ruff.toml or the [tool.ruff] table of pyproject.toml
+func ruffStyle(name, content string) string {
+	config, ok := decodeConfig(name, content)
+	if !ok {
+		return ""
+	}
+	if name == "pyproject.toml" {
+		ruff, isTable := lookup(config, "tool", "ruff").(map[string]interface{})
+		if !isTable {
+			return ""
+		}
+		config = ruff
+	}
+
+	var parts []string
+	if width := lookup(config, "line-length"); width != nil {
+		parts = append(parts, fmt.Sprintf("line length %v", width))
+	}
+	if width := lookup(config, "indent-width"); width != nil {
+		parts = append(parts, fmt.Sprintf("%v-space indent", width))
+	}
+	selected := stringList(lookup(config, "lint", "select"))
+	if len(selected) == 0 {
+		selected = stringList(lookup(config, "select"))
+	}
+	if len(selected) > 0 {
+		parts = append(parts, "rules "+strings.Join(selected, ", "))
+	}
+	return styleRule("Ruff", "ruff check . && ruff format .", parts)
+}
+
+// rubocopStyle reads the line length, target version and plugins of a RuboCop configuration
+func rubocopStyle(name, content string) string {
+	var parts []string
+	if config, ok := decodeConfig(name, content); ok {
+		if width := lookup(config, "Layout/LineLength", "Max"); width != nil {
+			parts = append(parts, fmt.Sprintf("max line length %v", width))
+		}
+		if version := lookup(config, "AllCops", "TargetRubyVersion"); version != nil {
+			parts = append(parts, fmt.Sprintf("Ruby %v", version))
+		}
+		plugins := append(stringList(lookup(config, "plugins")), stringList(lookup(config, "require"))...)
+		if len(plugins) > 0 {
+			parts = append(parts, "plugins "+strings.Join(plugins, ", "))
+		}
+	}
+	return styleRule("RuboCop", "bundle exec rubocop", parts)
+}
+
+// rustfmtStyle reads the width and indentation settings of rustfmt.toml
+func rustfmtStyle(name, content string) string {
+	var parts []string
+	if config, ok := decodeConfig(name, content); ok {
+		if width := lookup(config, "max_width"); width != nil {
+			parts = append(parts, fmt.Sprintf("max width %v", width))
+		}
+		if lookup(config, "hard_tabs") == true {
+			parts = append(parts, "indent with tabs")
+		} else if spaces := lookup(config, "tab_spaces"); spaces != nil {
+			parts = append(parts, fmt.Sprintf("%v-space indent", spaces))
+		}
+		if edition := lookup(config, "edition"); edition != nil {
+			parts = append(parts, fmt.Sprintf("edition %v", edition))
+		}
+	}
+	return styleRule("rustfmt", "cargo fmt", parts)
+}
+
+// checkstyleModule is a <module> element of a Checkstyle configuration
+type checkstyleModule struct {
+	Name       string `xml:"name,attr"`
+	Properties []struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:"value,attr"`
+	} `xml:"property"`
+	Modules []checkstyleModule `xml:"module"`
+}
+
+// checkstyleStyle reads the LineLength and Indentation checks of a Checkstyle configuration
+func checkstyleStyle(_, content string) string {
+	var root checkstyleModule
+	if err := xml.Unmarshal([]byte(content), &root); err != nil {
+		return ""
+	}
+
+	var parts []string
+	var visit func(m checkstyleModule)
+	visit = func(m checkstyleModule) {
+		for _, p := range m.Properties {
+			switch {
+			case m.Name == "LineLength" && p.Name == "max":
+				parts = append(parts, "max line length "+p.Value)
+			case m.Name == "Indentation" && p.Name == "basicOffset":
+				parts = append(parts, p.Value+"-space indent")
+			}
+		}
+		for _, child := range m.Modules {
+			visit(child)
+		}
+	}
+	visit(root)
+	return styleRule("Checkstyle", "", parts)
+}
+
+// styleRule formats a tool, the command that runs it and its settings as a single rule
+func styleRule(tool, command string, parts []string) string {
+	rule := tool
+	if command != "" {
+		rule += fmt.Sprintf(" (`%s`)", command)
+	}
+	if len(parts) > 0 {
+		rule += ": " + strings.Join(parts, ", ")
+	} else if command == "" {
+		return ""
+	}
+	return rule
+}
+
+// decodeConfig parses a TOML, JSON or YAML configuration file into a generic map.
+// Files without an extension are tried as YAML, which also covers JSON.
+func decodeConfig(name, content string) (map[string]interface{}, bool) {
+	config := make(map[string]interface{})
+	switch path.Ext(strings.TrimPrefix(name, ".")) {
+	case ".toml":
+		if _, err := toml.Decode(content, &config); err != nil {
+			return nil, false
+		}
+	case "", ".json", ".yaml", ".yml":
+		if err := yaml.Unmarshal([]byte(content), &config); err != nil {
+			return nil, false
+		}
+	default:
+		// JavaScript and other executable configurations cannot be read
+		return nil, false
+	}
+	return config, true
+}
+
+// lookup follows keys through nested maps and returns the value, or nil if missing
+func lookup(config map[string]interface{}, keys ...string) interface{} {
+	var value interface{} = config
+	for _, key := range keys {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+	return value
+}
+
+// stringList converts a string or a list of values into a list of strings
+func stringList(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			list = append(list, fmt.Sprint(item))
+		}
+		return list
+	case []string:
+		return v
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package detect
+
+import "testing"
+
+func TestDetectCodeStyle(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name: "no configuration",
+		},
+		{
+			name:  "editorconfig root section only",
+			files: map[string]string{".editorconfig": "root = true\n\n[*]\nindent_style = space\nindent_size = 2\ninsert_final_newline = true\n\n[Makefile]\nindent_style = tab\n"},
+			want:  "EditorConfig: indent with 2 spaces, end files with a newline",
+		},
+		{
+			name:  "prettier json",
+			files: map[string]string{".prettierrc.json": `{"singleQuote": true, "semi": false, "printWidth": 100}`},
+			want:  "Prettier (`npx prettier --write .`): print width 100, single quotes, no semicolons",
+		},
+		{
+			name:  "javascript prettier config yields the command only",
+			files: map[string]string{"prettier.config.js": "module.exports = {}"},
+			want:  "Prettier (`npx prettier --write .`)",
+		},
+		{
+			name:  "golangci v2 linters",
+			files: map[string]string{".golangci.yml": "version: \"2\"\nlinters:\n  enable: [errcheck, revive]\n"},
+			want:  "golangci-lint (`golangci-lint run`): linters errcheck, revive",
+		},
+		{
+			name:  "ruff in pyproject",
+			files: map[string]string{"pyproject.toml": "[tool.ruff]\nline-length = 100\n\n[tool.ruff.lint]\nselect = [\"E\", \"F\"]\n"},
+			want:  "Ruff (`ruff check . && ruff format .`): line length 100, rules E, F",
+		},
+		{
+			name:  "pyproject without ruff",
+			files: map[string]string{"pyproject.toml": "[project]\nname = \"x\"\n"},
+		},
+		{
+			name:  "checkstyle",
+			files: map[string]string{"config/checkstyle/checkstyle.xml": `<module name="Checker"><module name="TreeWalker"><module name="LineLength"><property name="max" value="120"/></module></module></module>`},
+			want:  "Checkstyle: max line length 120",
+		},
+		{
+			name: "several tools in source order",
+			files: map[string]string{
+				"rustfmt.toml":  "max_width = 80\nhard_tabs = true\n",
+				".editorconfig": "[*]\nend_of_line = lf\n",
+			},
+			want: "EditorConfig: LF line endings; rustfmt (`cargo fmt`): max width 80, indent with tabs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectFiles(t, tt.files).CodeStyle; got != tt.want {
+				t.Errorf("CodeStyle = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
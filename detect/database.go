@@ -0,0 +1,172 @@
+package detect
+
+import (
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dataStore describes a database or cache and the evidence that a project uses it
+type dataStore struct {
+	name         string   // answer value
+	images       []string // compose image names, without registry, namespace or tag
+	dependencies []string // driver dependency names as manifestDependencies reports them
+}
+
+// dataStores are listed in the order they are reported: databases first, then caches.
+// A dependency also covers the names below it, so "npgsql" matches
+// "npgsql.entityframeworkcore.postgresql" and "org.mariadb" matches "org.mariadb.jdbc".
+var dataStores = []dataStore{
+	{
+		name:   "PostgreSQL",
+		images: []string{"postgres", "postgresql", "postgis"},
+		dependencies: []string{
+			"github.com/jackc/pgx", "github.com/lib/pq",
+			"psycopg", "psycopg-binary", "psycopg2", "psycopg2-binary", "asyncpg",
+			"org.postgresql", "postgres", "tokio-postgres", "npgsql",
+			"pg",
+		},
+	},
+	{
+		name:   "MySQL",
+		images: []string{"mysql"},
+		dependencies: []string{
+			"github.com/go-sql-driver/mysql",
+			"mysqlclient", "pymysql", "mysql-connector-python", "aiomysql",
+			"com.mysql", "mysql", "mysql_async", "mysqlconnector", "pomelo.entityframeworkcore.mysql",
+			"mysql2",
+		},
+	},
+	{
+		name:         "MariaDB",
+		images:       []string{"mariadb"},
+		dependencies: []string{"org.mariadb", "mariadb"},
+	},
+	{
+		name:   "MongoDB",
+		images: []string{"mongo", "mongodb"},
+		dependencies: []string{
+			"go.mongodb.org/mongo-driver",
+			"pymongo", "motor",
+			"org.mongodb", "mongodb",
+			"mongoose",
+		},
+	},
+	{
+		name: "SQLite",
+		dependencies: []string{
+			"github.com/mattn/go-sqlite3", "modernc.org/sqlite",
+			"org.xerial:sqlite-jdbc", "rusqlite", "microsoft.data.sqlite", "microsoft.entityframeworkcore.sqlite",
+			"sqlite3", "better-sqlite3",
+		},
+	},
+	{
+		name:   "Redis",
+		images: []string{"redis", "redis-stack", "valkey"},
+		dependencies: []string{
+			"github.com/redis/go-redis", "github.com/go-redis/redis", "github.com/gomodule/redigo",
+			"redis", "io.lettuce", "org.springframework.boot:spring-boot-starter-data-redis",
+			"stackexchange.redis",
+			"ioredis",
+		},
+	},
+}
+
+// composeFiles are the base names of Docker Compose files
+var composeFiles = []string{
+	"docker-compose.yml", "docker-compose.yaml", "docker-compose.*.yml", "docker-compose.*.yaml",
+	"compose.yml", "compose.yaml", "compose.*.yml", "compose.*.yaml",
+}
+
+// driverManifests are the dependency manifests searched for database drivers, besides package.json
+var driverManifests = []string{
+	"go.mod", "requirements*.txt", "pyproject.toml", "setup.py", "Pipfile",
+	"pom.xml", "build.gradle", "build.gradle.kts", "Cargo.toml", "*.csproj",
+}
+
+// composeFile holds the services of a Docker Compose file
+type composeFile struct {
+	Services map[string]struct {
+		Image string `yaml:"image"`
+	} `yaml:"services"`
+}
+
+// detectDatabases returns every data store found in compose files and driver dependencies,
+// joined with ", ". deps holds the package.json dependencies, if any.
+func detectDatabases(s *scan, deps map[string]bool) string {
+	images := composeImages(s)
+
+	declared := make(map[string]bool)
+	for dep := range deps {
+		declared[dep] = true
+	}
+	for _, rel := range s.files {
+		if matchesAny(path.Base(rel), driverManifests) {
+			data, _ := s.read(rel)
+			for dep := range manifestDependencies(path.Base(rel), data) {
+				declared[dep] = true
+			}
+		}
+	}
+
+	var found []string
+	for _, store := range dataStores {
+		if usesDataStore(store, images, declared) {
+			found = append(found, store.name)
+		}
+	}
+	return strings.Join(found, ", ")
+}
+
+// usesDataStore reports whether any of the evidence points to store
+func usesDataStore(store dataStore, images, deps map[string]bool) bool {
+	for _, image := range store.images {
+		if images[image] {
+			return true
+		}
+	}
+	for dep := range deps {
+		for _, name := range store.dependencies {
+			if dep == name || strings.HasPrefix(dep, name+"/") || strings.HasPrefix(dep, name+".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// composeImages returns the image names used by services in compose files, reduced to
+// their last path element without tag or digest (e.g. "bitnami/postgresql:16" → "postgresql").
+// Files that cannot be parsed are ignored.
+func composeImages(s *scan) map[string]bool {
+	images := make(map[string]bool)
+	for _, rel := range s.files {
+		if !matchesAny(path.Base(rel), composeFiles) {
+			continue
+		}
+		data, ok := s.read(rel)
+		if !ok {
+			continue
+		}
+
+		var compose composeFile
+		if err := yaml.Unmarshal([]byte(data), &compose); err != nil {
+			continue
+		}
+		for _, service := range compose.Services {
+			image := strings.ToLower(service.Image)
+			if i := strings.Index(image, "@"); i >= 0 {
+				image = image[:i]
+			}
+			image = path.Base(image)
+			if i := strings.Index(image, ":"); i >= 0 {
+				image = image[:i]
+			}
+			if image != "" && image != "." {
+				images[image] = true
+			}
+		}
+	}
+	return images
+}
@@ -0,0 +1,89 @@
+package detect
+
+import "testing"
+
+func TestDetectDatabases(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name:  "no evidence",
+			files: map[string]string{"go.mod": "module x\n"},
+		},
+		{
+			name: "compose images with registry, namespace and tag",
+			files: map[string]string{
+				"go.mod":             "module x\n",
+				"docker-compose.yml": "services:\n  db:\n    image: docker.io/bitnami/postgresql:16\n  cache:\n    image: redis@sha256:abc\n",
+			},
+			want: "PostgreSQL, Redis",
+		},
+		{
+			name: "compose override file",
+			files: map[string]string{
+				"go.mod":               "module x\n",
+				"compose.override.yml": "services:\n  db:\n    image: mariadb:11\n",
+			},
+			want: "MariaDB",
+		},
+		{
+			name:  "go driver",
+			files: map[string]string{"go.mod": "module x\n\nrequire github.com/jackc/pgx/v5 v5.5.0\n"},
+			want:  "PostgreSQL",
+		},
+		{
+			name:  "python drivers",
+			files: map[string]string{"requirements.txt": "psycopg[binary]\npymongo\n"},
+			want:  "PostgreSQL, MongoDB",
+		},
+		{
+			name: "npm packages of a node backend",
+			files: map[string]string{
+				"package.json": `{"dependencies": {"express": "^4", "mysql2": "^3", "ioredis": "^5"}}`,
+			},
+			want: "MySQL, Redis",
+		},
+		{
+			name:  "go test helpers are not drivers",
+			files: map[string]string{"go.mod": "module x\n\nrequire (\n\tgithub.com/alicebob/miniredis/v2 v2.31.0\n\tgithub.com/testcontainers/testcontainers-go/modules/mongodb v0.30.0\n)\n"},
+		},
+		{
+			name:  "go driver with a major version",
+			files: map[string]string{"go.mod": "module x\n\nrequire github.com/redis/go-redis/v9 v9.5.1\n"},
+			want:  "Redis",
+		},
+		{
+			name:  "gradle coordinates",
+			files: map[string]string{"build.gradle": "dependencies {\n    implementation 'org.mariadb.jdbc:mariadb-java-client:3.3.2'\n    implementation 'redis.clients:jedis:5.1.0'\n}\n"},
+			want:  "MariaDB, Redis",
+		},
+		{
+			name:  "nuget packages",
+			files: map[string]string{"Api.csproj": `<Project><ItemGroup><PackageReference Include="Npgsql.EntityFrameworkCore.PostgreSQL" Version="8.0.0" /></ItemGroup></Project>`},
+			want:  "PostgreSQL",
+		},
+		{
+			name: "unparsable compose file",
+			files: map[string]string{
+				"go.mod":      "module x\n",
+				"compose.yml": "services: [",
+			},
+		},
+		{
+			name: "frontend only",
+			files: map[string]string{
+				"package.json": `{"dependencies": {"react": "^18", "redis": "^4"}}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectFiles(t, tt.files).BackendDatabase; got != tt.want {
+				t.Errorf("BackendDatabase = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
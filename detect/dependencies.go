@@ -0,0 +1,365 @@
+package detect
+
+import (
+	"encoding/xml"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// dependencyParsers read the declared dependency names of a manifest, keyed by base name
+// pattern. Names are lowercase; Go module paths drop their major version suffix, Maven
+// coordinates are listed as both "group" and "group:artifact", and Swift packages as the
+// "owner/repo" of their URL.
+var dependencyParsers = []struct {
+	files []string
+	parse func(name, content string) []string
+}{
+	{[]string{"go.mod"}, goModDependencies},
+	{[]string{"requirements*.txt"}, requirementsDependencies},
+	{[]string{"pyproject.toml"}, pyprojectDependencies},
+	{[]string{"Pipfile"}, pipfileDependencies},
+	{[]string{"setup.py"}, setupPyDependencies},
+	{[]string{"Cargo.toml"}, cargoDependencies},
+	{[]string{"pom.xml"}, pomDependencies},
+	{[]string{"build.gradle", "build.gradle.kts"}, gradleDependencies},
+	{[]string{"*.csproj"}, csprojDependencies},
+	{[]string{"composer.json"}, composerDependencies},
+	{[]string{"Gemfile"}, gemfileDependencies},
+	{[]string{"mix.exs"}, mixDependencies},
+	{[]string{"build.sbt", "plugins.sbt"}, sbtDependencies},
+	{[]string{"Package.swift"}, swiftPackageDependencies},
+}
+
+var (
+	goMajorVersion      = regexp.MustCompile(`/v[0-9]+$`)
+	requirementName     = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*`)
+	setupRequires       = regexp.MustCompile(`(?s)install_requires\s*=\s*\[(.*?)\]`)
+	quotedString        = regexp.MustCompile(`["']([^"']+)["']`)
+	gradleCoordinate    = regexp.MustCompile(`["']([A-Za-z0-9_.-]+):([A-Za-z0-9_.-]+)(?::[^"']*)?["']`)
+	gradlePluginID      = regexp.MustCompile(`\bid\s*\(?\s*["']([A-Za-z0-9_.-]+)["']`)
+	gemDeclaration      = regexp.MustCompile(`(?m)^\s*gem\s*\(?\s*["']([^"']+)["']`)
+	mixDependency       = regexp.MustCompile(`\{\s*:([a-z0-9_]+)\s*,`)
+	sbtModule           = regexp.MustCompile(`"([A-Za-z0-9_.-]+)"\s*%{1,3}\s*"([A-Za-z0-9_.-]+)"`)
+	swiftPackageURL     = regexp.MustCompile(`\.package\s*\([^)]*url:\s*"([^"]+)"`)
+	pythonNameSeparator = strings.NewReplacer("_", "-", ".", "-")
+)
+
+// manifestDependencies returns the dependency names declared in a manifest, or nil for
+// files that are not dependency manifests
+func manifestDependencies(name, content string) map[string]bool {
+	for _, parser := range dependencyParsers {
+		if !matchesAny(name, parser.files) {
+			continue
+		}
+		deps := make(map[string]bool)
+		for _, dep := range parser.parse(name, content) {
+			if dep = strings.ToLower(strings.TrimSpace(dep)); dep != "" {
+				deps[dep] = true
+			}
+		}
+		return deps
+	}
+	return nil
+}
+
+// goModDependencies reads the module paths of require directives, single or in blocks
+func goModDependencies(_, content string) []string {
+	var deps []string
+	inBlock := false
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case inBlock && fields[0] == ")":
+			inBlock = false
+			continue
+		case fields[0] == "require" && len(fields) > 1 && fields[1] == "(":
+			inBlock = true
+			continue
+		case fields[0] == "require" && len(fields) > 1:
+			fields = fields[1:]
+		case !inBlock:
+			continue
+		}
+		deps = append(deps, goMajorVersion.ReplaceAllString(fields[0], ""))
+	}
+	return deps
+}
+
+// requirementsDependencies reads the project names of a pip requirements file
+func requirementsDependencies(_, content string) []string {
+	var deps []string
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		if name := pythonRequirement(line); name != "" {
+			deps = append(deps, name)
+		}
+	}
+	return deps
+}
+
+// pythonRequirement returns the normalized project name of a PEP 508 requirement such as
+// "django-environ[s3]>=0.11; python_version>'3.8'", or "" for options like -r and -e
+func pythonRequirement(requirement string) string {
+	requirement = strings.TrimSpace(requirement)
+	if strings.HasPrefix(requirement, "-") {
+		return ""
+	}
+	return pythonNameSeparator.Replace(strings.ToLower(requirementName.FindString(requirement)))
+}
+
+// pyprojectDependencies reads PEP 621 dependency lists and Poetry dependency tables
+func pyprojectDependencies(name, content string) []string {
+	config, ok := decodeConfig(name, content)
+	if !ok {
+		return nil
+	}
+
+	var requirements []string
+	requirements = append(requirements, stringList(lookup(config, "project", "dependencies"))...)
+	if optional, ok := lookup(config, "project", "optional-dependencies").(map[string]interface{}); ok {
+		for _, group := range optional {
+			requirements = append(requirements, stringList(group)...)
+		}
+	}
+	if groups, ok := lookup(config, "dependency-groups").(map[string]interface{}); ok {
+		for _, group := range groups {
+			requirements = append(requirements, stringList(group)...)
+		}
+	}
+
+	var deps []string
+	for _, requirement := range requirements {
+		deps = append(deps, pythonRequirement(requirement))
+	}
+	for _, table := range poetryDependencyTables(config) {
+		for dep := range table {
+			deps = append(deps, pythonRequirement(dep))
+		}
+	}
+	return deps
+}
+
+// poetryDependencyTables returns the main, dev and group dependency tables of tool.poetry
+func poetryDependencyTables(config map[string]interface{}) []map[string]interface{} {
+	var tables []map[string]interface{}
+	for _, key := range []string{"dependencies", "dev-dependencies"} {
+		if table, ok := lookup(config, "tool", "poetry", key).(map[string]interface{}); ok {
+			tables = append(tables, table)
+		}
+	}
+	if groups, ok := lookup(config, "tool", "poetry", "group").(map[string]interface{}); ok {
+		for group := range groups {
+			if table, ok := lookup(groups, group, "dependencies").(map[string]interface{}); ok {
+				tables = append(tables, table)
+			}
+		}
+	}
+	return tables
+}
+
+// pipfileDependencies reads the package tables of a Pipfile
+func pipfileDependencies(_, content string) []string {
+	config, ok := decodeConfig("Pipfile.toml", content)
+	if !ok {
+		return nil
+	}
+	var deps []string
+	for _, key := range []string{"packages", "dev-packages"} {
+		if table, ok := config[key].(map[string]interface{}); ok {
+			for dep := range table {
+				deps = append(deps, pythonRequirement(dep))
+			}
+		}
+	}
+	return deps
+}
+
+// setupPyDependencies reads the quoted requirements of the install_requires list
+func setupPyDependencies(_, content string) []string {
+	var deps []string
+	for _, list := range setupRequires.FindAllStringSubmatch(content, -1) {
+		for _, quoted := range quotedString.FindAllStringSubmatch(list[1], -1) {
+			deps = append(deps, pythonRequirement(quoted[1]))
+		}
+	}
+	return deps
+}
+
+// cargoDependencies reads the crate names of every dependency table, including
+// workspace and target-specific ones
+func cargoDependencies(name, content string) []string {
+	config, ok := decodeConfig(name, content)
+	if !ok {
+		return nil
+	}
+
+	var tables []interface{}
+	for _, key := range []string{"dependencies", "dev-dependencies", "build-dependencies"} {
+		tables = append(tables, config[key], lookup(config, "workspace", key))
+		if targets, ok := config["target"].(map[string]interface{}); ok {
+			for target := range targets {
+				tables = append(tables, lookup(targets, target, key))
+			}
+		}
+	}
+
+	var deps []string
+	for _, table := range tables {
+		if table, ok := table.(map[string]interface{}); ok {
+			for dep := range table {
+				deps = append(deps, dep)
+			}
+		}
+	}
+	return deps
+}
+
+// mavenArtifact is a dependency, plugin or parent of a pom.xml
+type mavenArtifact struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+}
+
+// pomProject holds the parts of a pom.xml that name other artifacts
+type pomProject struct {
+	Parent               mavenArtifact   `xml:"parent"`
+	Dependencies         []mavenArtifact `xml:"dependencies>dependency"`
+	DependencyManagement []mavenArtifact `xml:"dependencyManagement>dependencies>dependency"`
+	Plugins              []mavenArtifact `xml:"build>plugins>plugin"`
+}
+
+// pomDependencies reads the parent, dependencies and build plugins of a pom.xml
+func pomDependencies(_, content string) []string {
+	var pom pomProject
+	if err := xml.Unmarshal([]byte(content), &pom); err != nil {
+		return nil
+	}
+
+	artifacts := append([]mavenArtifact{pom.Parent}, pom.Dependencies...)
+	artifacts = append(artifacts, pom.DependencyManagement...)
+	artifacts = append(artifacts, pom.Plugins...)
+
+	var deps []string
+	for _, a := range artifacts {
+		deps = append(deps, mavenCoordinates(a.GroupID, a.ArtifactID)...)
+	}
+	return deps
+}
+
+// gradleDependencies reads "group:artifact:version" coordinates and plugin ids
+func gradleDependencies(_, content string) []string {
+	var deps []string
+	for _, m := range gradleCoordinate.FindAllStringSubmatch(content, -1) {
+		deps = append(deps, mavenCoordinates(m[1], m[2])...)
+	}
+	for _, m := range gradlePluginID.FindAllStringSubmatch(content, -1) {
+		deps = append(deps, m[1])
+	}
+	return deps
+}
+
+// mavenCoordinates lists an artifact as "group" and "group:artifact"
+func mavenCoordinates(group, artifact string) []string {
+	group, artifact = strings.TrimSpace(group), strings.TrimSpace(artifact)
+	if group == "" {
+		return nil
+	}
+	if artifact == "" {
+		return []string{group}
+	}
+	return []string{group, group + ":" + artifact}
+}
+
+// csprojProject holds the SDK and references of an MSBuild project file
+type csprojProject struct {
+	Sdk        string `xml:"Sdk,attr"`
+	References []struct {
+		Include string `xml:"Include,attr"`
+	} `xml:"ItemGroup>PackageReference"`
+	Frameworks []struct {
+		Include string `xml:"Include,attr"`
+	} `xml:"ItemGroup>FrameworkReference"`
+}
+
+// csprojDependencies reads the SDK, package references and framework references
+func csprojDependencies(_, content string) []string {
+	var project csprojProject
+	if err := xml.Unmarshal([]byte(content), &project); err != nil {
+		return nil
+	}
+
+	deps := []string{project.Sdk}
+	for _, ref := range project.References {
+		deps = append(deps, ref.Include)
+	}
+	for _, ref := range project.Frameworks {
+		deps = append(deps, ref.Include)
+	}
+	return deps
+}
+
+// composerDependencies reads the package names of require and require-dev
+func composerDependencies(name, content string) []string {
+	config, ok := decodeConfig(name, content)
+	if !ok {
+		return nil
+	}
+	var deps []string
+	for _, key := range []string{"require", "require-dev"} {
+		if table, ok := config[key].(map[string]interface{}); ok {
+			for dep := range table {
+				deps = append(deps, dep)
+			}
+		}
+	}
+	return deps
+}
+
+// gemfileDependencies reads the names of gem declarations
+func gemfileDependencies(_, content string) []string {
+	return submatches(gemDeclaration, content)
+}
+
+// mixDependencies reads the atoms that start dependency tuples such as {:phoenix, "~> 1.7"}
+func mixDependencies(_, content string) []string {
+	return submatches(mixDependency, content)
+}
+
+// sbtDependencies reads "group" % "artifact" module IDs, including sbt plugins
+func sbtDependencies(_, content string) []string {
+	var deps []string
+	for _, m := range sbtModule.FindAllStringSubmatch(content, -1) {
+		deps = append(deps, mavenCoordinates(m[1], m[2])...)
+	}
+	return deps
+}
+
+// swiftPackageDependencies reads the owner/repo of package URLs,
+// e.g. "vapor/vapor" for https://github.com/vapor/vapor.git
+func swiftPackageDependencies(_, content string) []string {
+	var deps []string
+	for _, url := range submatches(swiftPackageURL, content) {
+		url = strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+		repo := path.Base(url)
+		owner := path.Base(path.Dir(url))
+		deps = append(deps, owner+"/"+repo)
+	}
+	return deps
+}
+
+// submatches returns the first capture group of every match of re in content
+func submatches(re *regexp.Regexp, content string) []string {
+	var values []string
+	for _, m := range re.FindAllStringSubmatch(content, -1) {
+		values = append(values, m[1])
+	}
+	return values
+}
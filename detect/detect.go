@@ -0,0 +1,186 @@
+package detect
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/mongoose84/proser/filesystem"
+	"github.com/mongoose84/proser/language"
+)
+
+// maxDepth limits how many directory levels below the root are scanned
+const maxDepth = 4
+
+// skipDirs are directories that hold dependencies, build output or tooling state
+var skipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"dist":         true,
+	"build":        true,
+	"target":       true,
+	"bin":          true,
+	"obj":          true,
+	"out":          true,
+	"venv":         true,
+	"__pycache__":  true,
+}
+
+// Result holds the answers inferred from the files of an existing project.
+// Fields are empty when nothing was detected.
+type Result struct {
+	ProjectType       string
+	CodeStyle         string // rules from linter and formatter configurations, joined with "; "
+	FrontendLanguage  string
+	FrontendFramework string
+	FrontendBuildTool string
+	BackendLanguage   string
+	BackendFramework  string
+	BackendDatabase   string // every detected store, joined with ", "
+	TestingFramework  string
+}
+
+// Answers returns the detected values keyed by question key
+func (r Result) Answers() map[string]string {
+	answers := make(map[string]string)
+	set := func(key, value string) {
+		if value != "" {
+			answers[key] = value
+		}
+	}
+	set("project_type", r.ProjectType)
+	set("code_style", r.CodeStyle)
+	set("frontend_language", r.FrontendLanguage)
+	set("frontend_framework", r.FrontendFramework)
+	set("frontend_build_tool", r.FrontendBuildTool)
+	set("backend_language", r.BackendLanguage)
+	set("backend_framework", r.BackendFramework)
+	set("backend_database", r.BackendDatabase)
+	set("testing_framework", r.TestingFramework)
+	return answers
+}
+
+// Detect scans the project below root and infers its stack
+func Detect(fsys filesystem.FileSystem, root string, registry *language.Registry) (Result, error) {
+	s, err := scanProject(fsys, root)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{CodeStyle: detectCodeStyle(s)}
+	frontend, backend := detectLanguages(s, registry)
+
+	// Frontend languages count as a frontend unless a server framework says otherwise, as
+	// Express does for JavaScript or Vapor for Swift
+	var deps map[string]bool
+	if frontend != nil {
+		deps = packageDependencies(s)
+		result.FrontendFramework = matchDependency(deps, frontendFrameworkRules)
+		result.FrontendBuildTool = matchDependency(deps, frontendBuildToolRules)
+		if backend == nil {
+			framework := matchDependency(deps, nodeBackendFrameworkRules)
+			if framework == "" {
+				framework = matchManifest(s, frontend, backendFrameworkRules)
+			}
+			if framework != "" {
+				backend = frontend
+				result.BackendFramework = framework
+				if result.FrontendFramework == "" {
+					frontend = nil
+					result.FrontendBuildTool = ""
+				}
+			}
+		}
+	}
+	if backend != nil && result.BackendFramework == "" {
+		result.BackendFramework = matchManifest(s, backend, backendFrameworkRules)
+	}
+	if backend != nil {
+		result.BackendDatabase = detectDatabases(s, deps)
+	}
+
+	if frontend != nil {
+		result.FrontendLanguage = frontend.DisplayName
+	}
+	if backend != nil {
+		result.BackendLanguage = backend.DisplayName
+	}
+
+	switch {
+	case frontend != nil && backend != nil:
+		result.ProjectType = "fullstack"
+		result.TestingFramework = frontend.TestFramework
+	case frontend != nil:
+		result.ProjectType = "frontend"
+		result.TestingFramework = frontend.TestFramework
+	case backend != nil:
+		result.ProjectType = "backend"
+		result.TestingFramework = backend.TestFramework
+	}
+
+	// JavaScript test runners are only relevant when JavaScript or TypeScript is in use
+	if deps != nil {
+		if testing := matchDependency(deps, jsTestingFrameworkRules); testing != "" {
+			result.TestingFramework = testing
+		}
+	}
+	return result, nil
+}
+
+// scan is the list of files found in a project
+type scan struct {
+	fsys  filesystem.FileSystem
+	root  string
+	files []string // relative to root, slash-separated
+}
+
+// scanProject walks root and records every file that is not ignored and lies outside
+// of skipped directories
+func scanProject(fsys filesystem.FileSystem, root string) (*scan, error) {
+	s := &scan{fsys: fsys, root: filepath.Clean(root)}
+	err := filesystem.NewIgnoreWalker(fsys).Walk(s.root, func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.root, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if info.IsDir() {
+			name := info.Name()
+			if skipDirs[name] || strings.HasPrefix(name, ".") || strings.Count(rel, "/") >= maxDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		s.files = append(s.files, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+	return s, nil
+}
+
+// read returns the content of a file relative to the root, or false if it cannot be read
+func (s *scan) read(rel string) (string, bool) {
+	data, err := s.fsys.ReadFile(filepath.Join(s.root, filepath.FromSlash(rel)))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// matchContextFile reports whether the file at rel matches a LanguageInfo context file entry.
+// Entries with a slash name a path below the root; others match the base name and may be globs.
+func matchContextFile(rel, entry string) bool {
+	if strings.Contains(entry, "/") {
+		return rel == entry || strings.HasPrefix(rel, entry+"/")
+	}
+	matched, err := path.Match(entry, path.Base(rel))
+	return err == nil && matched
+}
@@ -0,0 +1,156 @@
+package detect
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mongoose84/proser/filesystem"
+	"github.com/mongoose84/proser/language"
+)
+
+const root = "/project"
+
+// detectFiles runs Detect on a project made of the given files
+func detectFiles(t *testing.T, files map[string]string) Result {
+	t.Helper()
+	fsys := filesystem.NewMemoryFileSystem()
+	if err := fsys.MkdirAll(root, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for rel, content := range files {
+		if err := fsys.WriteFile(filepath.Join(root, rel), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	result, err := Detect(fsys, root, language.NewDefaultRegistry())
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	return result
+}
+
+func TestDetectLanguages(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  Result
+	}{
+		{
+			name: "empty project",
+		},
+		{
+			name:  "go backend",
+			files: map[string]string{"go.mod": "module x\n", "main.go": "", "internal/app/app.go": ""},
+			want:  Result{ProjectType: "backend", BackendLanguage: "Go", TestingFramework: "Go testing"},
+		},
+		{
+			name:  "sources without a context file are ignored",
+			files: map[string]string{"server.go": "", "script.py": ""},
+		},
+		{
+			name:  "typescript frontend",
+			files: map[string]string{"package.json": "{}", "tsconfig.json": "{}", "src/index.ts": "", "src/app.ts": ""},
+			want:  Result{ProjectType: "frontend", FrontendLanguage: "TypeScript", TestingFramework: "Jest"},
+		},
+		{
+			name: "fullstack takes the frontend test framework",
+			files: map[string]string{
+				"pyproject.toml":    "[project]\nname = \"x\"\n",
+				"app/main.py":       "",
+				"web/package.json":  "{}",
+				"web/src/index.js":  "",
+				"web/src/routes.js": "",
+			},
+			want: Result{ProjectType: "fullstack", FrontendLanguage: "JavaScript", BackendLanguage: "Python", TestingFramework: "Jest"},
+		},
+		{
+			name: "most source files win among backends",
+			files: map[string]string{
+				"go.mod": "module x\n", "server.go": "",
+				"Cargo.toml": "[package]\nname = \"x\"\n", "src/main.rs": "", "src/lib.rs": "", "src/db.rs": "",
+			},
+			want: Result{ProjectType: "backend", BackendLanguage: "Rust", TestingFramework: "cargo test"},
+		},
+		{
+			name:  "flutter app is a frontend",
+			files: map[string]string{"pubspec.yaml": "name: app\n", "lib/main.dart": "", "lib/home.dart": ""},
+			want:  Result{ProjectType: "frontend", FrontendLanguage: "Dart", TestingFramework: "dart test"},
+		},
+		{
+			name:  "swift app is a frontend",
+			files: map[string]string{"Package.swift": "let package = Package(name: \"App\")\n", "Sources/App/App.swift": ""},
+			want:  Result{ProjectType: "frontend", FrontendLanguage: "Swift", TestingFramework: "XCTest"},
+		},
+		{
+			name: "swift with a server framework is a backend",
+			files: map[string]string{
+				"Package.swift":          ".package(url: \"https://github.com/vapor/vapor.git\", from: \"4.89.0\"),\n",
+				"Sources/App/main.swift": "",
+			},
+			want: Result{ProjectType: "backend", BackendLanguage: "Swift", BackendFramework: "Vapor", TestingFramework: "XCTest"},
+		},
+		{
+			name: "shared headers leave the decision to the sources",
+			files: map[string]string{
+				"CMakeLists.txt": "project(x CXX)\n", "src/main.cpp": "", "src/app.cpp": "",
+				"include/app.h": "", "include/util.h": "", "include/log.h": "",
+			},
+			want: Result{ProjectType: "backend", BackendLanguage: "C++", TestingFramework: "GoogleTest"},
+		},
+		{
+			name: "java project with gradle kotlin scripts",
+			files: map[string]string{
+				"settings.gradle.kts": "rootProject.name = \"app\"\n", "build.gradle.kts": "plugins { java }\n",
+				"src/main/java/app/App.java": "",
+			},
+			want: Result{ProjectType: "backend", BackendLanguage: "Java", TestingFramework: "JUnit"},
+		},
+		{
+			name: "kotlin project with gradle kotlin scripts",
+			files: map[string]string{
+				"settings.gradle.kts": "rootProject.name = \"app\"\n", "build.gradle.kts": "plugins { kotlin(\"jvm\") }\n",
+				"src/main/kotlin/app/App.kt": "",
+			},
+			want: Result{ProjectType: "backend", BackendLanguage: "Kotlin", TestingFramework: "JUnit"},
+		},
+		{
+			name: "dependency and hidden directories are skipped",
+			files: map[string]string{
+				"go.mod":                      "module x\n",
+				"node_modules/x/package.json": "{}",
+				".cache/tsconfig.json":        "{}",
+			},
+			want: Result{ProjectType: "backend", BackendLanguage: "Go", TestingFramework: "Go testing"},
+		},
+		{
+			name: "gitignored files are skipped",
+			files: map[string]string{
+				".gitignore":             "generated/\n",
+				"go.mod":                 "module x\n",
+				"generated/package.json": "{}",
+			},
+			want: Result{ProjectType: "backend", BackendLanguage: "Go", TestingFramework: "Go testing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectFiles(t, tt.files); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Detect() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResultAnswers(t *testing.T) {
+	result := Result{ProjectType: "backend", BackendLanguage: "Go", BackendDatabase: "PostgreSQL, Redis"}
+	want := map[string]string{
+		"project_type":     "backend",
+		"backend_language": "Go",
+		"backend_database": "PostgreSQL, Redis",
+	}
+	if got := result.Answers(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Answers() = %v, want %v", got, want)
+	}
+}
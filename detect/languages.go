@@ -0,0 +1,70 @@
+package detect
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/mongoose84/proser/language"
+)
+
+// contextFileScore is the weight of a matching context file compared to a single source file
+const contextFileScore = 10
+
+// languageScore ranks a language by the files found for it
+type languageScore struct {
+	lang         *language.LanguageInfo
+	contextFiles int // distinct ContextFiles entries matched
+	sourceFiles  int // files with one of the FileExtensions, except Gradle scripts
+}
+
+func (s languageScore) score() int {
+	return s.contextFiles*contextFileScore + s.sourceFiles
+}
+
+// detectLanguages returns the most prominent frontend and backend languages, if any.
+// A language only counts when one of its context files exists; source files rank it.
+func detectLanguages(s *scan, registry *language.Registry) (frontend, backend *language.LanguageInfo) {
+	var scores []languageScore
+	for _, lang := range registry.Languages() {
+		ls := languageScore{lang: lang}
+		for _, entry := range lang.ContextFiles {
+			for _, rel := range s.files {
+				if matchContextFile(rel, entry) {
+					ls.contextFiles++
+					break
+				}
+			}
+		}
+		if ls.contextFiles == 0 {
+			continue
+		}
+		for _, rel := range s.files {
+			// Gradle scripts configure Java builds as often as Kotlin ones, so they rank neither
+			if strings.HasSuffix(rel, ".gradle.kts") {
+				continue
+			}
+			ext := path.Ext(rel)
+			for _, langExt := range lang.FileExtensions {
+				if ext == langExt {
+					ls.sourceFiles++
+					break
+				}
+			}
+		}
+		scores = append(scores, ls)
+	}
+
+	// Highest score first; ties keep the registry's name order
+	sort.SliceStable(scores, func(i, j int) bool { return scores[i].score() > scores[j].score() })
+
+	for _, ls := range scores {
+		if ls.lang.Frontend && frontend == nil {
+			frontend = ls.lang
+		}
+		if !ls.lang.Frontend && backend == nil {
+			backend = ls.lang
+		}
+	}
+	return frontend, backend
+}
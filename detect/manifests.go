@@ -0,0 +1,82 @@
+package detect
+
+import (
+	"path"
+
+	"github.com/mongoose84/proser/language"
+)
+
+// manifestRule infers an answer from the dependencies declared in manifests
+type manifestRule struct {
+	language     string   // LanguageInfo.Name the rule applies to
+	files        []string // manifest base names, may be globs
+	dependencies []string // dependency names as read by manifestDependencies, lowercase
+	answer       string
+}
+
+// Rules are checked in order; more specific frameworks come first
+var backendFrameworkRules = []manifestRule{
+	{"go", []string{"go.mod"}, []string{"github.com/gin-gonic/gin"}, "Gin"},
+	{"go", []string{"go.mod"}, []string{"github.com/labstack/echo"}, "Echo"},
+	{"go", []string{"go.mod"}, []string{"github.com/go-chi/chi"}, "Chi"},
+	{"go", []string{"go.mod"}, []string{"github.com/gofiber/fiber"}, "Fiber"},
+	{"python", pythonManifests, []string{"fastapi"}, "FastAPI"},
+	{"python", pythonManifests, []string{"django"}, "Django"},
+	{"python", pythonManifests, []string{"flask"}, "Flask"},
+	{"java", javaManifests, []string{"org.springframework.boot"}, "Spring Boot"},
+	{"rust", []string{"Cargo.toml"}, []string{"axum"}, "Axum"},
+	{"rust", []string{"Cargo.toml"}, []string{"actix-web"}, "Actix Web"},
+	{"csharp", []string{"*.csproj"}, []string{"microsoft.net.sdk.web", "microsoft.aspnetcore.app"}, "ASP.NET Core"},
+	{"kotlin", javaManifests, []string{"io.ktor", "io.ktor.plugin"}, "Ktor"},
+	{"kotlin", javaManifests, []string{"org.springframework.boot"}, "Spring Boot"},
+	{"php", []string{"composer.json"}, []string{"laravel/framework"}, "Laravel"},
+	{"php", []string{"composer.json"}, []string{"symfony/framework-bundle"}, "Symfony"},
+	{"ruby", []string{"Gemfile"}, []string{"rails"}, "Rails"},
+	{"ruby", []string{"Gemfile"}, []string{"sinatra"}, "Sinatra"},
+	{"elixir", []string{"mix.exs"}, []string{"phoenix"}, "Phoenix"},
+	{"scala", []string{"build.sbt", "plugins.sbt"}, []string{"com.typesafe.play", "org.playframework"}, "Play"},
+	{"swift", []string{"Package.swift"}, []string{"vapor/vapor"}, "Vapor"},
+}
+
+var (
+	pythonManifests = []string{"requirements*.txt", "pyproject.toml", "setup.py", "Pipfile"}
+	javaManifests   = []string{"pom.xml", "build.gradle", "build.gradle.kts"}
+)
+
+// matchManifest returns the answer of the first rule for lang whose dependency is declared
+// in one of its manifests. Names are compared whole, so pytest-django does not imply Django.
+func matchManifest(s *scan, lang *language.LanguageInfo, rules []manifestRule) string {
+	parsed := make(map[string]map[string]bool)
+	for _, rule := range rules {
+		if rule.language != lang.Name {
+			continue
+		}
+		for _, rel := range s.files {
+			if !matchesAny(path.Base(rel), rule.files) {
+				continue
+			}
+			deps, ok := parsed[rel]
+			if !ok {
+				data, _ := s.read(rel)
+				deps = manifestDependencies(path.Base(rel), data)
+				parsed[rel] = deps
+			}
+			for _, dep := range rule.dependencies {
+				if deps[dep] {
+					return rule.answer
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// matchesAny reports whether name matches one of the glob patterns
+func matchesAny(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package detect
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestDetectBackendFramework(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name:  "gin in a require block",
+			files: map[string]string{"go.mod": "module x\n\nrequire (\n\tgithub.com/gin-gonic/gin v1.9.1\n)\n"},
+			want:  "Gin",
+		},
+		{
+			name:  "echo with a major version suffix",
+			files: map[string]string{"go.mod": "module x\n\nrequire github.com/labstack/echo/v4 v4.11.0\n"},
+			want:  "Echo",
+		},
+		{
+			name:  "go helper module is not the framework",
+			files: map[string]string{"go.mod": "module x\n\nrequire github.com/gin-contrib/cors v1.5.0 // for github.com/gin-gonic/gin\n"},
+		},
+		{
+			name:  "flask project with django helpers",
+			files: map[string]string{"requirements.txt": "Flask==3.0\npytest-django>=4\ndjango-environ  # settings\n"},
+			want:  "Flask",
+		},
+		{
+			name:  "django with extras and markers",
+			files: map[string]string{"requirements.txt": "requests\n", "requirements-dev.txt": "-r requirements.txt\nDjango[argon2]>=5.0; python_version >= '3.10'\n"},
+			want:  "Django",
+		},
+		{
+			name: "pyproject dependencies",
+			files: map[string]string{"pyproject.toml": "[project]\nname = \"x\"\ndependencies = [\"fastapi>=0.110\", \"uvicorn\"]\n" +
+				"[project.optional-dependencies]\ntest = [\"pytest-django\"]\n"},
+			want: "FastAPI",
+		},
+		{
+			name:  "poetry dependencies",
+			files: map[string]string{"pyproject.toml": "[tool.poetry.dependencies]\npython = \"^3.12\"\nFlask = \"^3.0\"\n"},
+			want:  "Flask",
+		},
+		{
+			name:  "setup.py install_requires",
+			files: map[string]string{"setup.py": "setup(name='x', install_requires=['django>=4', 'requests'])\n"},
+			want:  "Django",
+		},
+		{
+			name:  "axum helper crate only",
+			files: map[string]string{"Cargo.toml": "[package]\nname = \"x\"\n\n[dependencies]\naxum-extra = \"0.9\"\nactix-web = \"4\"\n"},
+			want:  "Actix Web",
+		},
+		{
+			name:  "axum",
+			files: map[string]string{"Cargo.toml": "[package]\nname = \"x\"\n\n[dependencies]\naxum = { version = \"0.7\" }\n"},
+			want:  "Axum",
+		},
+		{
+			name: "spring boot parent",
+			files: map[string]string{"pom.xml": "<project><parent><groupId>org.springframework.boot</groupId>" +
+				"<artifactId>spring-boot-starter-parent</artifactId></parent></project>"},
+			want: "Spring Boot",
+		},
+		{
+			name:  "spring boot gradle plugin",
+			files: map[string]string{"build.gradle": "plugins {\n  id 'org.springframework.boot' version '3.2.0'\n}\n"},
+			want:  "Spring Boot",
+		},
+		{
+			name:  "asp.net core sdk",
+			files: map[string]string{"Api.csproj": "<Project Sdk=\"Microsoft.NET.Sdk.Web\"></Project>"},
+			want:  "ASP.NET Core",
+		},
+		{
+			name:  "console project with an aspnetcore package",
+			files: map[string]string{"Tool.csproj": "<Project Sdk=\"Microsoft.NET.Sdk\"><ItemGroup><PackageReference Include=\"Microsoft.AspNetCore.Http.Abstractions\" /></ItemGroup></Project>"},
+		},
+		{
+			name:  "ktor",
+			files: map[string]string{"build.gradle.kts": "dependencies {\n  implementation(\"io.ktor:ktor-server-core:2.3.7\")\n}\n", "src/main/kotlin/App.kt": ""},
+			want:  "Ktor",
+		},
+		{
+			name:  "laravel",
+			files: map[string]string{"composer.json": `{"require": {"php": "^8.2", "laravel/framework": "^11.0"}}`},
+			want:  "Laravel",
+		},
+		{
+			name:  "rails",
+			files: map[string]string{"Gemfile": "source 'https://rubygems.org'\ngem 'rails', '~> 7.1'\ngem 'rspec-rails'\n"},
+			want:  "Rails",
+		},
+		{
+			name:  "rspec-rails alone",
+			files: map[string]string{"Gemfile": "gem \"sinatra\"\ngem \"rspec-rails\"\n"},
+			want:  "Sinatra",
+		},
+		{
+			name:  "phoenix",
+			files: map[string]string{"mix.exs": "defp deps do\n  [\n    {:phoenix, \"~> 1.7\"},\n    {:ecto_sql, \"~> 3.10\"}\n  ]\nend\n"},
+			want:  "Phoenix",
+		},
+		{
+			name:  "phoenix helper only",
+			files: map[string]string{"mix.exs": "defp deps do\n  [{:phoenix_html, \"~> 4.0\"}]\nend\n"},
+		},
+		{
+			name:  "play",
+			files: map[string]string{"build.sbt": "lazy val root = project\n", "project/plugins.sbt": "addSbtPlugin(\"org.playframework\" % \"sbt-plugin\" % \"3.0.1\")\n"},
+			want:  "Play",
+		},
+		{
+			name:  "vapor",
+			files: map[string]string{"Package.swift": ".package(url: \"https://github.com/vapor/vapor.git\", from: \"4.89.0\"),\n"},
+			want:  "Vapor",
+		},
+		{
+			name:  "express",
+			files: map[string]string{"package.json": `{"dependencies": {"express": "^4.18.0"}}`},
+			want:  "Express",
+		},
+		{
+			name:  "express-validator without express",
+			files: map[string]string{"package.json": `{"dependencies": {"express-validator": "^7.0.0", "fastify": "^4"}}`},
+			want:  "Fastify",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectFiles(t, tt.files).BackendFramework; got != tt.want {
+				t.Errorf("BackendFramework = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManifestDependencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "go.mod skips replace and module lines",
+			file:    "go.mod",
+			content: "module example.com/x\n\ngo 1.22\n\nrequire (\n\tgithub.com/go-chi/chi/v5 v5.0.0\n\tgolang.org/x/text v0.14.0 // indirect\n)\n\nreplace example.com/y => ../y\n",
+			want:    []string{"github.com/go-chi/chi", "golang.org/x/text"},
+		},
+		{
+			name:    "requirements normalize names",
+			file:    "requirements.txt",
+			content: "# deps\n-e .\nDjango_Environ==0.11\nzope.interface\n",
+			want:    []string{"django-environ", "zope-interface"},
+		},
+		{
+			name:    "gradle coordinates and plugins",
+			file:    "build.gradle.kts",
+			content: "plugins { id(\"io.ktor.plugin\") version \"2.3.7\" }\ndependencies { implementation(\"ch.qos.logback:logback-classic:1.4.14\") }\n",
+			want:    []string{"ch.qos.logback", "ch.qos.logback:logback-classic", "io.ktor.plugin"},
+		},
+		{
+			name: "not a manifest",
+			file: "README.md",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := manifestDependencies(tt.file, tt.content)
+			var got []string
+			for dep := range deps {
+				got = append(got, dep)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("manifestDependencies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package detect
+
+import (
+	"encoding/json"
+	"path"
+)
+
+// dependencyRule maps a dependency name to the answer it implies
+type dependencyRule struct {
+	dependency string
+	answer     string
+}
+
+// Meta-frameworks come before the libraries they build on, so next wins over react
+var frontendFrameworkRules = []dependencyRule{
+	{"next", "Next.js"},
+	{"nuxt", "Nuxt"},
+	{"@angular/core", "Angular"},
+	{"svelte", "Svelte"},
+	{"vue", "Vue"},
+	{"react", "React"},
+}
+
+var frontendBuildToolRules = []dependencyRule{
+	{"vite", "Vite"},
+	{"react-scripts", "Create React App"},
+	{"webpack", "Webpack"},
+	{"parcel", "Parcel"},
+}
+
+var nodeBackendFrameworkRules = []dependencyRule{
+	{"@nestjs/core", "NestJS"},
+	{"fastify", "Fastify"},
+	{"express", "Express"},
+}
+
+// Unit test runners come before end-to-end tools
+var jsTestingFrameworkRules = []dependencyRule{
+	{"vitest", "Vitest"},
+	{"jest", "Jest"},
+	{"mocha", "Mocha"},
+	{"@playwright/test", "Playwright"},
+	{"cypress", "Cypress"},
+}
+
+// packageJSON holds the dependency sections of a package.json file
+type packageJSON struct {
+	Dependencies    map[string]string `json:"dependencies"`
+	DevDependencies map[string]string `json:"devDependencies"`
+}
+
+// packageDependencies returns the dependencies and devDependencies of every
+// package.json in the project, so workspaces in subdirectories are included.
+// Files that cannot be parsed are ignored.
+func packageDependencies(s *scan) map[string]bool {
+	deps := make(map[string]bool)
+	for _, rel := range s.files {
+		if path.Base(rel) != "package.json" {
+			continue
+		}
+		data, ok := s.read(rel)
+		if !ok {
+			continue
+		}
+
+		var pkg packageJSON
+		if err := json.Unmarshal([]byte(data), &pkg); err != nil {
+			continue
+		}
+		for name := range pkg.Dependencies {
+			deps[name] = true
+		}
+		for name := range pkg.DevDependencies {
+			deps[name] = true
+		}
+	}
+	return deps
+}
+
+// matchDependency returns the answer of the first rule whose dependency is present
+func matchDependency(deps map[string]bool, rules []dependencyRule) string {
+	for _, rule := range rules {
+		if deps[rule.dependency] {
+			return rule.answer
+		}
+	}
+	return ""
+}
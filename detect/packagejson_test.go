@@ -0,0 +1,91 @@
+package detect
+
+import "testing"
+
+func TestDetectPackageJSON(t *testing.T) {
+	tests := []struct {
+		name          string
+		packageJSON   string
+		wantType      string
+		wantFramework string
+		wantBuildTool string
+		wantTesting   string
+		wantBackend   string
+	}{
+		{
+			name:          "react with vite and vitest",
+			packageJSON:   `{"dependencies": {"react": "^18"}, "devDependencies": {"vite": "^5", "vitest": "^1", "jest": "^29"}}`,
+			wantType:      "frontend",
+			wantFramework: "React",
+			wantBuildTool: "Vite",
+			wantTesting:   "Vitest",
+		},
+		{
+			name:          "next wins over react",
+			packageJSON:   `{"dependencies": {"next": "14", "react": "^18"}}`,
+			wantType:      "frontend",
+			wantFramework: "Next.js",
+			wantTesting:   "Jest",
+		},
+		{
+			name:          "create react app",
+			packageJSON:   `{"dependencies": {"react": "^18", "react-scripts": "5"}}`,
+			wantType:      "frontend",
+			wantFramework: "React",
+			wantBuildTool: "Create React App",
+			wantTesting:   "Jest",
+		},
+		{
+			name:        "server framework without a frontend is a node backend",
+			packageJSON: `{"dependencies": {"express": "^4"}, "devDependencies": {"mocha": "^10"}}`,
+			wantType:    "backend",
+			wantTesting: "Mocha",
+			wantBackend: "Express",
+		},
+		{
+			name:          "server and frontend framework make a fullstack project",
+			packageJSON:   `{"dependencies": {"@nestjs/core": "^10", "vue": "^3"}}`,
+			wantType:      "fullstack",
+			wantFramework: "Vue",
+			wantTesting:   "Jest",
+			wantBackend:   "NestJS",
+		},
+		{
+			name:        "invalid package.json",
+			packageJSON: `{"dependencies": `,
+			wantType:    "frontend",
+			wantTesting: "Jest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detectFiles(t, map[string]string{"package.json": tt.packageJSON, "src/index.js": ""})
+			if got.ProjectType != tt.wantType {
+				t.Errorf("ProjectType = %q, want %q", got.ProjectType, tt.wantType)
+			}
+			if got.FrontendFramework != tt.wantFramework {
+				t.Errorf("FrontendFramework = %q, want %q", got.FrontendFramework, tt.wantFramework)
+			}
+			if got.FrontendBuildTool != tt.wantBuildTool {
+				t.Errorf("FrontendBuildTool = %q, want %q", got.FrontendBuildTool, tt.wantBuildTool)
+			}
+			if got.TestingFramework != tt.wantTesting {
+				t.Errorf("TestingFramework = %q, want %q", got.TestingFramework, tt.wantTesting)
+			}
+			if got.BackendFramework != tt.wantBackend {
+				t.Errorf("BackendFramework = %q, want %q", got.BackendFramework, tt.wantBackend)
+			}
+		})
+	}
+}
+
+func TestDetectWorkspacePackages(t *testing.T) {
+	got := detectFiles(t, map[string]string{
+		"package.json":          `{"private": true}`,
+		"apps/web/package.json": `{"dependencies": {"svelte": "^4"}}`,
+	})
+	if got.FrontendFramework != "Svelte" {
+		t.Errorf("FrontendFramework = %q, want Svelte from the workspace package", got.FrontendFramework)
+	}
+}
@@ -10,6 +10,9 @@ type FileSystem interface {
 	// WriteFile writes data to a file at the specified path
 	WriteFile(path string, data []byte, perm os.FileMode) error
 
+	// ReadFile reads the contents of the file at the specified path
+	ReadFile(path string) ([]byte, error)
+
 	// MkdirAll creates a directory hierarchy
 	MkdirAll(path string, perm os.FileMode) error
 
@@ -18,4 +21,7 @@ type FileSystem interface {
 
 	// Stat returns file information
 	Stat(path string) (fs.FileInfo, error)
+
+	// Remove deletes a file or an empty directory
+	Remove(path string) error
 }
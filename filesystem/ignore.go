@@ -0,0 +1,241 @@
+package filesystem
+
+import (
+	"bufio"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IgnoreFileNames are the per-directory ignore files honored by IgnoreWalker, in order of
+// precedence: patterns in later files override earlier ones in the same directory
+var IgnoreFileNames = []string{".gitignore", ".proserignore"}
+
+// gitExcludeFile holds repository-wide ignore patterns that are not committed
+const gitExcludeFile = ".git/info/exclude"
+
+// IgnoreWalker walks a directory tree like FileSystem.Walk but leaves out what git would
+// ignore. It reads .git/info/exclude at the root and .gitignore and .proserignore files
+// in every directory, following gitignore semantics: the last matching pattern wins,
+// deeper files override shallower ones, "!" negates, and files below an ignored
+// directory cannot be re-included. The .git directory itself is always skipped.
+type IgnoreWalker struct {
+	fsys FileSystem
+}
+
+// NewIgnoreWalker creates a walker that reads files and ignore patterns through fsys
+func NewIgnoreWalker(fsys FileSystem) *IgnoreWalker {
+	return &IgnoreWalker{fsys: fsys}
+}
+
+// ignoreRule is one pattern from an ignore file
+type ignoreRule struct {
+	base    string // slash-separated directory of the ignore file, relative to the top of the walk
+	pattern *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// Walk calls fn for every path below root that is not ignored. Returning filepath.SkipDir
+// from fn skips a directory as with FileSystem.Walk.
+func (w *IgnoreWalker) Walk(root string, fn func(path string, info fs.FileInfo, err error) error) error {
+	return w.WalkFrom(root, root, fn)
+}
+
+// WalkFrom walks root, a directory inside top, applying the ignore files of top and of
+// every directory between them as well, as if the walk had started at top.
+func (w *IgnoreWalker) WalkFrom(top, root string, fn func(path string, info fs.FileInfo, err error) error) error {
+	top, root = filepath.Clean(top), filepath.Clean(root)
+	start, err := filepath.Rel(top, root)
+	if err != nil || strings.HasPrefix(start, "..") {
+		// root is not inside top, so only its own ignore files apply
+		top, start = root, "."
+	}
+	start = filepath.ToSlash(start)
+
+	rules := map[string][]ignoreRule{
+		".": w.readRules(filepath.Join(top, filepath.FromSlash(gitExcludeFile)), "."),
+	}
+
+	// Directories above root are never visited, so load their ignore files up front
+	if start != "." {
+		dir := "."
+		w.loadDirRules(rules, top, dir)
+		parts := strings.Split(start, "/")
+		for _, part := range parts[:len(parts)-1] {
+			dir = path.Join(dir, part)
+			w.loadDirRules(rules, top, dir)
+		}
+	}
+
+	return w.fsys.Walk(root, func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return fn(p, info, err)
+		}
+		rel, err := filepath.Rel(top, p)
+		if err != nil {
+			return fn(p, info, err)
+		}
+		rel = filepath.ToSlash(rel)
+
+		if p != root {
+			if info.IsDir() && info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			if ignored(rules, rel, info.IsDir()) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+
+		if info.IsDir() {
+			w.loadDirRules(rules, top, rel)
+		}
+		return fn(p, info, nil)
+	})
+}
+
+// loadDirRules adds the rules of the ignore files in dir, relative to top
+func (w *IgnoreWalker) loadDirRules(rules map[string][]ignoreRule, top, dir string) {
+	for _, name := range IgnoreFileNames {
+		file := filepath.Join(top, filepath.FromSlash(dir), name)
+		rules[dir] = append(rules[dir], w.readRules(file, dir)...)
+	}
+}
+
+// readRules parses an ignore file, returning no rules if it cannot be read
+func (w *IgnoreWalker) readRules(file, base string) []ignoreRule {
+	data, err := w.fsys.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		if rule, ok := parseIgnoreRule(scanner.Text(), base); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// ignored reports whether rel is ignored by the rules of its ancestor directories
+func ignored(rules map[string][]ignoreRule, rel string, isDir bool) bool {
+	var dirs []string
+	for dir := path.Dir(rel); ; dir = path.Dir(dir) {
+		dirs = append(dirs, dir)
+		if dir == "." {
+			break
+		}
+	}
+
+	// Shallow rules first, so deeper ignore files have the last word
+	result := false
+	for i := len(dirs) - 1; i >= 0; i-- {
+		for _, rule := range rules[dirs[i]] {
+			if rule.matches(rel, isDir) {
+				result = !rule.negate
+			}
+		}
+	}
+	return result
+}
+
+// matches reports whether the rule applies to rel, a path relative to the top of the walk
+func (r ignoreRule) matches(rel string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	if r.base != "." {
+		if !strings.HasPrefix(rel, r.base+"/") {
+			return false
+		}
+		rel = strings.TrimPrefix(rel, r.base+"/")
+	}
+	return r.pattern.MatchString(rel)
+}
+
+// parseIgnoreRule converts a gitignore line into a rule; blank lines and comments yield none
+func parseIgnoreRule(line, base string) (ignoreRule, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	rule := ignoreRule{base: base}
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return ignoreRule{}, false
+	}
+
+	// A slash at the start or in the middle anchors the pattern to the ignore file's directory
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	expr := globToRegexp(line)
+	if !anchored {
+		expr = "(?:.*/)?" + expr
+	}
+	pattern, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.pattern = pattern
+	return rule, true
+}
+
+// globToRegexp translates a gitignore glob into a regular expression. "*" and "?" stay
+// within one path segment, "**" spans segments and character classes are kept.
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "/**") && i+3 == len(glob):
+			sb.WriteString("/.*")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '\\' && i+1 < len(glob):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(glob[i])))
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return sb.String()
+}
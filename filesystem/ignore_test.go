@@ -0,0 +1,199 @@
+package filesystem
+
+import (
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIgnoreRuleMatches(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		base  string
+		rel   string
+		isDir bool
+		want  bool
+	}{
+		{name: "plain name matches at any depth", line: "*.log", base: ".", rel: "a/b/debug.log", want: true},
+		{name: "star stays within a segment", line: "a*c", base: ".", rel: "ab/c", want: false},
+		{name: "question mark", line: "?.txt", base: ".", rel: "x.txt", want: true},
+		{name: "character class", line: "[!a]b", base: ".", rel: "cb", want: true},
+		{name: "negated character class", line: "[!a]b", base: ".", rel: "ab", want: false},
+		{name: "leading slash anchors", line: "/build", base: ".", rel: "src/build", isDir: true, want: false},
+		{name: "leading slash matches at the base", line: "/build", base: ".", rel: "build", isDir: true, want: true},
+		{name: "middle slash anchors", line: "docs/*.md", base: ".", rel: "x/docs/a.md", want: false},
+		{name: "middle slash matches at the base", line: "docs/*.md", base: ".", rel: "docs/a.md", want: true},
+		{name: "directory-only skips files", line: "out/", base: ".", rel: "out", want: false},
+		{name: "directory-only matches directories", line: "out/", base: ".", rel: "a/out", isDir: true, want: true},
+		{name: "leading double star", line: "**/cache", base: ".", rel: "a/b/cache", want: true},
+		{name: "trailing double star", line: "logs/**", base: ".", rel: "logs/a/b.txt", want: true},
+		{name: "inner double star", line: "a/**/z", base: ".", rel: "a/z", want: true},
+		{name: "pattern relative to its file", line: "/gen", base: "pkg", rel: "pkg/gen", isDir: true, want: true},
+		{name: "pattern outside its file's directory", line: "gen", base: "pkg", rel: "gen", isDir: true, want: false},
+		{name: "escaped hash", line: `\#notes`, base: ".", rel: "#notes", want: true},
+		{name: "escaped bang", line: `\!important`, base: ".", rel: "!important", want: true},
+		{name: "trailing spaces are trimmed", line: "tmp   ", base: ".", rel: "tmp", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := parseIgnoreRule(tt.line, tt.base)
+			if !ok {
+				t.Fatalf("parseIgnoreRule(%q) yielded no rule", tt.line)
+			}
+			if got := rule.matches(tt.rel, tt.isDir); got != tt.want {
+				t.Errorf("%q matches %q = %v, want %v", tt.line, tt.rel, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIgnoreRuleSkipsNonPatterns(t *testing.T) {
+	for _, line := range []string{"", "   ", "# comment", "/", "!"} {
+		if _, ok := parseIgnoreRule(line, "."); ok {
+			t.Errorf("parseIgnoreRule(%q) yielded a rule", line)
+		}
+	}
+}
+
+func TestIgnoreWalker(t *testing.T) {
+	const root = "/project"
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name:  "nothing ignored",
+			files: map[string]string{"a.go": "", "pkg/b.go": ""},
+			want:  []string{"a.go", "pkg/b.go"},
+		},
+		{
+			name: "negation re-includes a file",
+			files: map[string]string{
+				".gitignore":    "*.log\n!keep.log\n",
+				"debug.log":     "",
+				"keep.log":      "",
+				"sub/keep.log":  "",
+				"sub/other.log": "",
+			},
+			want: []string{".gitignore", "keep.log", "sub/keep.log"},
+		},
+		{
+			name: "files below an ignored directory stay ignored",
+			files: map[string]string{
+				".gitignore":       "vendor/\n!vendor/keep.go\n",
+				"vendor/keep.go":   "",
+				"vendor/x/y.go":    "",
+				"main.go":          "",
+				"src/vendor.go":    "",
+				"src/vendor/z.txt": "",
+			},
+			want: []string{".gitignore", "main.go", "src/vendor.go"},
+		},
+		{
+			name: "anchored pattern only applies at the root",
+			files: map[string]string{
+				".gitignore":      "/dist\n",
+				"dist/app.js":     "",
+				"web/dist/app.js": "",
+			},
+			want: []string{".gitignore", "web/dist/app.js"},
+		},
+		{
+			name: "deeper ignore files override shallower ones",
+			files: map[string]string{
+				".gitignore":     "*.gen.go\n",
+				"a.gen.go":       "",
+				"pkg/.gitignore": "!*.gen.go\n/local/\n",
+				"pkg/b.gen.go":   "",
+				"pkg/local/c.go": "",
+			},
+			want: []string{".gitignore", "pkg/.gitignore", "pkg/b.gen.go"},
+		},
+		{
+			name: "proserignore overrides gitignore",
+			files: map[string]string{
+				".gitignore":    "docs/\n",
+				".proserignore": "!docs/\nfixtures/\n",
+				"docs/a.md":     "",
+				"fixtures/x":    "",
+			},
+			want: []string{".gitignore", ".proserignore", "docs/a.md"},
+		},
+		{
+			name: "git directory and exclude file",
+			files: map[string]string{
+				".git/info/exclude": "secret.txt\n",
+				".git/HEAD":         "",
+				"secret.txt":        "",
+				"public.txt":        "",
+			},
+			want: []string{"public.txt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := NewMemoryFileSystem()
+			for relPath, content := range tt.files {
+				if err := fsys.WriteFile(filepath.Join(root, filepath.FromSlash(relPath)), []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got := walkedFiles(t, NewIgnoreWalker(fsys), root, root)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("walked %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIgnoreWalkerWalkFrom(t *testing.T) {
+	const root = "/project"
+	fsys := NewMemoryFileSystem()
+	for relPath, content := range map[string]string{
+		".gitignore":              "*.tmp\n",
+		"apps/.gitignore":         "/web/build/\n",
+		"apps/web/index.js":       "",
+		"apps/web/scratch.tmp":    "",
+		"apps/web/build/out.js":   "",
+		"apps/web/src/build/x.js": "",
+	} {
+		if err := fsys.WriteFile(filepath.Join(root, filepath.FromSlash(relPath)), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := walkedFiles(t, NewIgnoreWalker(fsys), root, filepath.Join(root, "apps", "web"))
+	want := []string{"apps/web/index.js", "apps/web/src/build/x.js"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("walked %v, want %v", got, want)
+	}
+}
+
+// walkedFiles returns the files visited by walking root inside top, relative to top
+func walkedFiles(t *testing.T, w *IgnoreWalker, top, root string) []string {
+	t.Helper()
+	var files []string
+	err := w.WalkFrom(top, root, func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			rel, err := filepath.Rel(top, p)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
@@ -45,9 +45,12 @@ func (mfs *MemoryFileSystem) MkdirAll(path string, perm os.FileMode) error {
 	// Normalize path
 	path = filepath.Clean(path)
 
-	// Create all parent directories
+	// Create all parent directories, keeping the leading separator of absolute paths
 	parts := strings.Split(path, string(filepath.Separator))
 	currentPath := ""
+	if filepath.IsAbs(path) {
+		currentPath = string(filepath.Separator)
+	}
 	for _, part := range parts {
 		if part == "" {
 			continue
@@ -93,7 +96,12 @@ func (mfs *MemoryFileSystem) Walk(root string, fn func(path string, info fs.File
 	sort.Strings(paths)
 
 	// Call the walk function for each path
+	var skipped []string
 	for _, path := range paths {
+		if underAny(path, skipped) {
+			continue
+		}
+
 		var info fs.FileInfo
 		if _, isFile := mfs.files[path]; isFile {
 			info = &memoryFileInfo{
@@ -111,6 +119,9 @@ func (mfs *MemoryFileSystem) Walk(root string, fn func(path string, info fs.File
 		if err := fn(path, info, nil); err != nil {
 			if err == filepath.SkipDir {
 				// Skip this directory and its contents
+				if info.IsDir() {
+					skipped = append(skipped, path)
+				}
 				continue
 			}
 			return err
@@ -120,6 +131,16 @@ func (mfs *MemoryFileSystem) Walk(root string, fn func(path string, info fs.File
 	return nil
 }
 
+// underAny reports whether path lies inside one of dirs
+func underAny(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
 // Stat returns file information for a path
 func (mfs *MemoryFileSystem) Stat(path string) (fs.FileInfo, error) {
 	path = filepath.Clean(path)
@@ -149,15 +170,45 @@ func (mfs *MemoryFileSystem) Stat(path string) (fs.FileInfo, error) {
 		}, nil
 	}
 
-	return nil, fmt.Errorf("path does not exist: %s", path)
+	return nil, fmt.Errorf("path does not exist: %s: %w", path, fs.ErrNotExist)
+}
+
+// Remove deletes an in-memory file or empty directory
+func (mfs *MemoryFileSystem) Remove(path string) error {
+	path = filepath.Clean(path)
+
+	if _, exists := mfs.files[path]; exists {
+		delete(mfs.files, path)
+		return nil
+	}
+
+	if !mfs.dirs[path] {
+		return fmt.Errorf("path does not exist: %s: %w", path, fs.ErrNotExist)
+	}
+
+	// Directories must be empty before they can be removed
+	prefix := path + string(filepath.Separator)
+	for file := range mfs.files {
+		if strings.HasPrefix(file, prefix) {
+			return fmt.Errorf("directory not empty: %s", path)
+		}
+	}
+	for dir := range mfs.dirs {
+		if strings.HasPrefix(dir, prefix) {
+			return fmt.Errorf("directory not empty: %s", path)
+		}
+	}
+
+	delete(mfs.dirs, path)
+	return nil
 }
 
-// ReadFile reads a file from memory (helper for testing)
+// ReadFile reads a file from memory
 func (mfs *MemoryFileSystem) ReadFile(path string) ([]byte, error) {
 	path = filepath.Clean(path)
 	data, exists := mfs.files[path]
 	if !exists {
-		return nil, fmt.Errorf("file not found: %s", path)
+		return nil, fmt.Errorf("file not found: %s: %w", path, fs.ErrNotExist)
 	}
 	return data, nil
 }
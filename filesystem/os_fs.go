@@ -19,6 +19,11 @@ func (fs *OsFileSystem) WriteFile(path string, data []byte, perm os.FileMode) er
 	return os.WriteFile(path, data, perm)
 }
 
+// ReadFile reads the contents of a file
+func (fs *OsFileSystem) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
 // MkdirAll creates a directory hierarchy
 func (fs *OsFileSystem) MkdirAll(path string, perm os.FileMode) error {
 	return os.MkdirAll(path, perm)
@@ -33,3 +38,8 @@ func (fs *OsFileSystem) Walk(root string, fn func(path string, info fs.FileInfo,
 func (fs *OsFileSystem) Stat(path string) (fs.FileInfo, error) {
 	return os.Stat(path)
 }
+
+// Remove deletes a file or an empty directory
+func (fs *OsFileSystem) Remove(path string) error {
+	return os.Remove(path)
+}
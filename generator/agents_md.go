@@ -9,7 +9,8 @@ import (
 
 // AgentsMdGenerator generates an AGENTS.md file at the project root.
 // Per the PROSE Explicit Hierarchy constraint, AGENTS.md lives at the project
-// directory level (not in every subdirectory). The generated file follows
+// directory level; scoped files in module directories are opt-in and written by
+// NestedAgentsMdGenerator. The generated file follows
 // PROSE principles: Progressive Disclosure, Explicit Hierarchy, and Safety Boundaries.
 type AgentsMdGenerator struct{}
 
@@ -22,48 +23,65 @@ func (g *AgentsMdGenerator) Name() string {
 func (g *AgentsMdGenerator) Generate(ctx GenerateContext) (map[string]string, error) {
 	cfg := ctx.Config
 
+	tree, err := scanRepositoryTree(ctx.FS, ctx.TargetPath, ctx.TargetPath, treeDepth(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	var modules []moduleDir
+	if cfg.Structure.NestedAgents {
+		if modules, err = findModuleDirs(ctx.FS, ctx.TargetPath, cfg.Structure); err != nil {
+			return nil, err
+		}
+	}
+
 	var sb strings.Builder
+	sb.WriteString(managedRegionsNotice)
 
 	// Header with purpose statement
-	sb.WriteString(fmt.Sprintf("# %s\n\n", cfg.General.ProjectName))
-	if cfg.General.Description != "" {
-		sb.WriteString(fmt.Sprintf("**Purpose**: %s\n\n", cfg.General.Description))
-	}
+	writeManagedSection(&sb, "header", func(sb *strings.Builder) {
+		sb.WriteString(fmt.Sprintf("# %s\n\n", cfg.General.ProjectName))
+		if cfg.General.Description != "" {
+			sb.WriteString(fmt.Sprintf("**Purpose**: %s\n\n", cfg.General.Description))
+		}
+	})
 
 	// Project Overview
-	g.writeProjectOverview(&sb, cfg)
+	writeManagedSection(&sb, "project-overview", func(sb *strings.Builder) { g.writeProjectOverview(sb, cfg) })
 
 	// Repository Structure
-	g.writeRepositoryStructure(&sb, cfg)
+	writeManagedSection(&sb, "repository-structure", func(sb *strings.Builder) { g.writeRepositoryStructure(sb, ctx, tree) })
 
 	// Tech Stack
-	g.writeTechStack(&sb, cfg)
+	writeManagedSection(&sb, "tech-stack", func(sb *strings.Builder) { g.writeTechStack(sb, cfg) })
 
 	// Development Guidelines
-	g.writeDevelopmentGuidelines(&sb, cfg)
+	writeManagedSection(&sb, "development-guidelines", func(sb *strings.Builder) { g.writeDevelopmentGuidelines(sb, cfg) })
 
 	// Instructions Hierarchy (if applicable)
-	g.writeInstructionsHierarchy(&sb, cfg)
+	writeManagedSection(&sb, "instructions-hierarchy", func(sb *strings.Builder) { g.writeInstructionsHierarchy(sb, cfg) })
 
 	// Agent Boundaries
-	g.writeAgentBoundaries(&sb, cfg)
+	writeManagedSection(&sb, "agent-boundaries", func(sb *strings.Builder) { g.writeAgentBoundaries(sb, cfg) })
 
 	// Progressive Disclosure
-	g.writeProgressiveDisclosure(&sb, cfg)
+	writeManagedSection(&sb, "progressive-disclosure", func(sb *strings.Builder) { g.writeProgressiveDisclosure(sb, cfg, modules) })
 
 	// Common Tasks
-	g.writeCommonTasks(&sb, cfg)
+	writeManagedSection(&sb, "common-tasks", func(sb *strings.Builder) { g.writeCommonTasks(sb, discoverTasks(ctx.FS, ctx.TargetPath)) })
 
 	// References
-	g.writeReferences(&sb, cfg)
+	writeManagedSection(&sb, "references", func(sb *strings.Builder) { g.writeReferences(sb, cfg) })
 
 	// Context Engineering Notes
-	g.writeContextEngineering(&sb)
+	writeManagedSection(&sb, "context-engineering", func(sb *strings.Builder) { g.writeContextEngineering(sb) })
 
 	// Footer
-	sb.WriteString("---\n\n")
-	sb.WriteString("*This AGENTS.md file was generated by [PROSER](https://github.com/mongoose84/proser) ")
-	sb.WriteString("following [PROSE](https://danielmeppiel.github.io/awesome-ai-native/docs/prose/) principles.*\n")
+	writeManagedSection(&sb, "footer", func(sb *strings.Builder) {
+		sb.WriteString("---\n\n")
+		sb.WriteString("*This AGENTS.md file was generated by [PROSER](https://github.com/mongoose84/proser) ")
+		sb.WriteString("following [PROSE](https://danielmeppiel.github.io/awesome-ai-native/docs/prose/) principles.*\n")
+	})
 
 	return map[string]string{
 		"AGENTS.md": sb.String(),
@@ -111,36 +129,19 @@ func (g *AgentsMdGenerator) writeProjectOverview(sb *strings.Builder, cfg config
 	sb.WriteString("\n")
 }
 
-func (g *AgentsMdGenerator) writeRepositoryStructure(sb *strings.Builder, cfg config.ProjectConfig) {
+func (g *AgentsMdGenerator) writeRepositoryStructure(sb *strings.Builder, ctx GenerateContext, tree *treeEntry) {
 	sb.WriteString("## Repository Structure\n\n")
-	sb.WriteString("*Note: This is a typical structure. Your actual project layout may vary.*\n\n")
-	sb.WriteString("```\n")
-	sb.WriteString(fmt.Sprintf("%s/\n", cfg.General.ProjectName))
-
-	if cfg.HasBackend() && cfg.HasFrontend() {
-		sb.WriteString("├── backend/             # Server-side code\n")
-		sb.WriteString("├── frontend/            # Client-side code\n")
-		sb.WriteString("├── shared/              # Shared types and utilities\n")
-	} else if cfg.HasBackend() {
-		sb.WriteString("├── cmd/                 # Application entry points\n")
-		sb.WriteString("├── internal/            # Private application code\n")
-		sb.WriteString("├── pkg/                 # Public library code\n")
-		sb.WriteString("├── api/                 # API definitions\n")
-	} else if cfg.HasFrontend() {
-		sb.WriteString("├── src/                 # Source code\n")
-		sb.WriteString("│   ├── components/      # UI components\n")
-		sb.WriteString("│   ├── pages/           # Page components\n")
-		sb.WriteString("│   └── utils/           # Utilities\n")
-		sb.WriteString("├── public/              # Static assets\n")
+	depth := treeDepth(ctx.Config)
+	if depth == 1 {
+		sb.WriteString("Top-level layout of the repository:\n\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("Layout of the repository, %d levels deep:\n\n", depth))
 	}
 
-	if cfg.Testing.Framework != "" {
-		sb.WriteString("├── tests/               # Test files\n")
-	}
-	sb.WriteString("├── .github/             # GitHub Copilot configuration\n")
-	sb.WriteString("│   └── instructions/    # Domain-specific instructions\n")
-	sb.WriteString("└── AGENTS.md            # This file\n")
-	sb.WriteString("```\n\n")
+	// .github and AGENTS.md are written by proser, so list them even before they exist
+	addTreeEntry(tree, &treeEntry{name: ".github", dir: true, comment: "GitHub Copilot configuration"})
+	addTreeEntry(tree, &treeEntry{name: "AGENTS.md", comment: "This file"})
+	renderTree(sb, ctx.Config.General.ProjectName, tree)
 }
 
 func (g *AgentsMdGenerator) writeTechStack(sb *strings.Builder, cfg config.ProjectConfig) {
@@ -231,18 +232,32 @@ func (g *AgentsMdGenerator) writeAgentBoundaries(sb *strings.Builder, cfg config
 	sb.WriteString("\n")
 }
 
-func (g *AgentsMdGenerator) writeProgressiveDisclosure(sb *strings.Builder, cfg config.ProjectConfig) {
+func (g *AgentsMdGenerator) writeProgressiveDisclosure(sb *strings.Builder, cfg config.ProjectConfig, modules []moduleDir) {
 	sb.WriteString("## Progressive Disclosure\n\n")
 	sb.WriteString("1. Start: [README.md](README.md)\n")
 	sb.WriteString("2. Global: [copilot-instructions.md](.github/copilot-instructions.md)\n")
+	step := 3
 	if cfg.HasBackend() || cfg.HasFrontend() || cfg.Testing.Framework != "" {
-		sb.WriteString("3. Domain-specific: [.github/instructions/](.github/instructions/)\n")
+		sb.WriteString(fmt.Sprintf("%d. Domain-specific: [.github/instructions/](.github/instructions/)\n", step))
+		step++
+	}
+	if len(modules) > 0 {
+		sb.WriteString(fmt.Sprintf("%d. Directory-specific: the AGENTS.md in the directory you work in\n", step))
+		for _, module := range modules {
+			sb.WriteString(fmt.Sprintf("   - [%s/](%s/AGENTS.md) - %s\n", module.rel, module.rel, module.kind))
+		}
 	}
 	sb.WriteString("\n")
 }
 
-func (g *AgentsMdGenerator) writeCommonTasks(sb *strings.Builder, cfg config.ProjectConfig) {
-	// Removed - too generic, users know their workflows
+func (g *AgentsMdGenerator) writeCommonTasks(sb *strings.Builder, tasks []commonTask) {
+	// Only commands the project actually defines are listed; nothing is invented
+	if len(tasks) == 0 {
+		return
+	}
+
+	sb.WriteString("## Common Tasks\n\n")
+	writeTaskTable(sb, tasks)
 }
 
 func (g *AgentsMdGenerator) writeReferences(sb *strings.Builder, cfg config.ProjectConfig) {
@@ -3,6 +3,8 @@ package generator
 import (
 	"fmt"
 	"strings"
+
+	"github.com/mongoose84/proser/language"
 )
 
 // BackendInstructionsGenerator generates backend-specific instructions
@@ -20,7 +22,11 @@ func (g *BackendInstructionsGenerator) Generate(ctx GenerateContext) (map[string
 	}
 
 	cfg := ctx.Config
-	lang := strings.ToLower(cfg.Backend.Language)
+	languages, err := ctx.registry()
+	if err != nil {
+		return nil, err
+	}
+	lang, _ := languages.LookupLanguage(cfg.Backend.Language) // nil for languages the registry does not know
 
 	var sb strings.Builder
 
@@ -35,58 +41,25 @@ func (g *BackendInstructionsGenerator) Generate(ctx GenerateContext) (map[string
 	sb.WriteString("Inherits from [global instructions](../copilot-instructions.md).\n\n")
 
 	// --- Context Loading ---
-	sb.WriteString("## Context Loading\n")
-	switch lang {
-	case "go":
-		sb.WriteString("Review [module dependencies](../../go.mod) and\n")
-		sb.WriteString("[application structure](../../main.go) before starting.\n\n")
-	case "python":
-		sb.WriteString("Review [dependencies](../../requirements.txt) and\n")
-		sb.WriteString("[application structure](../../) before starting.\n\n")
-	case "java":
-		sb.WriteString("Review [build dependencies](../../pom.xml) and\n")
-		sb.WriteString("[application structure](../../src/main/java/) before starting.\n\n")
-	case "javascript", "typescript", "node", "node.js", "js", "ts":
-		sb.WriteString("Review [dependencies](../../package.json) and\n")
-		sb.WriteString("[application structure](../../src/) before starting.\n\n")
-	default:
-		sb.WriteString("Review [project dependencies](../../) and\n")
-		sb.WriteString("[application structure](../../) before starting.\n\n")
-	}
+	writeContextLoading(&sb, ctx, languageContextGroups(lang), "before starting")
 
 	// --- Deterministic Requirements ---
 	sb.WriteString("## Deterministic Requirements\n")
 
 	// Language-specific requirements
-	switch lang {
-	case "go":
-		sb.WriteString("- Follow Effective Go conventions and idioms\n")
-		sb.WriteString("- Use interfaces to define behavior contracts\n")
-		sb.WriteString("- Implement resource cleanup with `defer`\n")
-		sb.WriteString("- Use `context.Context` for request scoping and cancellation\n")
-	case "python":
-		sb.WriteString("- Follow PEP 8 style guidelines\n")
-		sb.WriteString("- Use type hints for function signatures\n")
-		sb.WriteString("- Use context managers for resource management\n")
-		sb.WriteString("- Use asyncio for async operations when appropriate\n")
-	case "java":
-		sb.WriteString("- Follow Java naming conventions (camelCase, PascalCase)\n")
-		sb.WriteString("- Use try-with-resources for resource management\n")
-		sb.WriteString("- Apply dependency injection and SOLID principles\n")
-	case "javascript", "typescript", "node", "node.js", "js", "ts":
-		sb.WriteString("- Use async/await for asynchronous operations\n")
-		sb.WriteString("- Follow ES6+ module patterns\n")
-		sb.WriteString("- Implement proper error handling with try-catch\n")
-	case "rust", "rs":
-		sb.WriteString("- Use Result and Option types for error handling\n")
-		sb.WriteString("- Follow Rust ownership and borrowing patterns\n")
-	default:
+	if lang != nil {
+		writeBullets(&sb, lang.Guidelines)
+	} else {
 		sb.WriteString(fmt.Sprintf("- Follow %s best practices and idioms\n", cfg.Backend.Language))
 	}
 
 	// Framework-specific requirements
 	if cfg.Backend.Framework != "" && cfg.Backend.Framework != "None" {
-		sb.WriteString(fmt.Sprintf("- Follow %s patterns and conventions\n", cfg.Backend.Framework))
+		if fw, ok := languages.LookupFramework(cfg.Backend.Framework); ok {
+			writeBullets(&sb, fw.Guidelines)
+		} else {
+			sb.WriteString(fmt.Sprintf("- Follow %s patterns and conventions\n", cfg.Backend.Framework))
+		}
 	}
 
 	// Universal backend requirements
@@ -104,24 +77,16 @@ func (g *BackendInstructionsGenerator) Generate(ctx GenerateContext) (map[string
 	}
 	sb.WriteString("\n")
 
+	// --- Best Practices ---
+	writeBestPractices(&sb, lang)
+
 	// --- Structured Output ---
 	sb.WriteString("## Structured Output\n")
 	sb.WriteString("Generate code with:\n")
 
-	switch lang {
-	case "go":
-		sb.WriteString("- [ ] Wrapped errors with context\n")
-		sb.WriteString("- [ ] Table-driven unit tests\n")
-	case "python":
-		sb.WriteString("- [ ] Specific exception types\n")
-		sb.WriteString("- [ ] Pytest-style unit tests\n")
-	case "java":
-		sb.WriteString("- [ ] Custom exception hierarchy\n")
-		sb.WriteString("- [ ] JUnit tests with appropriate mocking\n")
-	case "javascript", "typescript", "node", "node.js", "js", "ts":
-		sb.WriteString("- [ ] Typed error objects\n")
-		sb.WriteString("- [ ] Unit tests with Jest or Mocha\n")
-	default:
+	if lang != nil {
+		writeChecklist(&sb, lang.OutputChecklist)
+	} else {
 		sb.WriteString("- [ ] Comprehensive error handling\n")
 		sb.WriteString("- [ ] Unit tests with appropriate framework\n")
 	}
@@ -135,24 +100,10 @@ func (g *BackendInstructionsGenerator) Generate(ctx GenerateContext) (map[string
 	}, nil
 }
 
-// backendApplyTo returns the applyTo glob for a given backend language
-func backendApplyTo(lang string) string {
-	switch lang {
-	case "go":
-		return "**/*.go"
-	case "python":
-		return "**/*.py"
-	case "java":
-		return "**/*.java"
-	case "javascript", "node.js", "node", "js":
-		return "**/*.js"
-	case "typescript", "ts":
-		return "**/*.ts"
-	case "rust", "rs":
-		return "**/*.rs"
-	case "c#", "csharp":
-		return "**/*.cs"
-	default:
+// backendApplyTo returns the applyTo glob for a backend language's source files
+func backendApplyTo(lang *language.LanguageInfo) string {
+	if lang == nil || len(lang.FileExtensions) == 0 {
 		return "**/*.{go,py,java,rs,js,ts}"
 	}
+	return extensionGlob(lang.FileExtensions)
 }
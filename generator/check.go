@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mongoose84/proser/filesystem"
+)
+
+// CheckResult is a generated file compared with its on-disk counterpart
+type CheckResult struct {
+	PlannedFile
+	Generator string
+	Generated string // generator output, before managed regions were merged with the file on disk
+}
+
+// Stale reports whether the file on disk is missing or differs from a fresh generation
+func (r CheckResult) Stale() bool {
+	return r.Status != StatusUnchanged
+}
+
+// Check runs generators into an in-memory filesystem and compares their output with
+// ctx.FS, without touching the files on disk. Managed regions are merged the same way
+// a real run would, so content outside them never makes a file stale. Informational
+// sections are left out of the comparison.
+func Check(generators []Generator, ctx GenerateContext) ([]CheckResult, error) {
+	mem := filesystem.NewMemoryFileSystem()
+	memWriter := NewWriter(mem)
+	diskWriter := NewWriter(ctx.FS)
+
+	var results []CheckResult
+	for _, gen := range generators {
+		written, err := memWriter.RunGenerator(gen, ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		files := make(map[string]string, len(written))
+		for _, result := range written {
+			data, err := mem.ReadFile(filepath.Join(ctx.TargetPath, result.Path))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read generated file %s: %w", result.Path, err)
+			}
+			files[result.Path] = string(data)
+		}
+
+		planned, err := diskWriter.Plan(ctx.TargetPath, files)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare files for generator %s: %w", gen.Name(), err)
+		}
+		for _, pf := range planned {
+			if pf.Status == StatusChanged && withoutInformational(pf.Current) == withoutInformational(pf.Content) {
+				pf.Status = StatusUnchanged
+			}
+			results = append(results, CheckResult{PlannedFile: pf, Generator: gen.Name(), Generated: files[pf.Path]})
+		}
+	}
+	return results, nil
+}
+
+// informationalHeadings start sections that describe the repository as it is rather than
+// the configuration. Adding a file changes them, so check and diff ignore them and only
+// update refreshes them.
+var informationalHeadings = []string{
+	"## Repository Structure",
+	"## Contents",
+	"## Common Tasks",
+	"## Context Loading",
+}
+
+// emptyManagedSection matches the markers left of a managed section whose content was dropped
+var emptyManagedSection = regexp.MustCompile(`(?m)^<!-- proser:begin section=[A-Za-z0-9_.-]+ -->\n<!-- proser:end -->\n`)
+
+// withoutInformational drops the informational sections from content, together with managed
+// sections that held nothing else. A section runs from its heading to the next level-two
+// heading or proser marker outside a code block.
+func withoutInformational(content string) string {
+	var sb strings.Builder
+	var fence codeFence
+	skipping := false
+	for _, line := range strings.SplitAfter(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !fence.skip(line) && (strings.HasPrefix(trimmed, "## ") || strings.HasPrefix(trimmed, "<!-- proser:")) {
+			skipping = false
+			for _, heading := range informationalHeadings {
+				if trimmed == heading {
+					skipping = true
+				}
+			}
+		}
+		if !skipping {
+			sb.WriteString(line)
+		}
+	}
+	return emptyManagedSection.ReplaceAllString(sb.String(), "")
+}
@@ -0,0 +1,40 @@
+package generator
+
+import "testing"
+
+func TestWithoutInformational(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "section up to the next heading",
+			content: "# Title\n## Repository Structure\n\n```\nproject/\n```\n\n## Tech Stack\n- Go\n",
+			want:    "# Title\n## Tech Stack\n- Go\n",
+		},
+		{
+			name:    "managed section holding only the informational section",
+			content: ManagedSection("repository-structure", "## Repository Structure\n\n- a\n") + "after\n",
+			want:    "after\n",
+		},
+		{
+			name:    "headings inside code blocks",
+			content: "## Contents\n```\n## Tech Stack\n```\nlisting\n",
+			want:    "",
+		},
+		{
+			name:    "other sections are kept",
+			content: "## Tech Stack\n- Go\n",
+			want:    "## Tech Stack\n- Go\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withoutInformational(tt.content); got != tt.want {
+				t.Errorf("withoutInformational() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
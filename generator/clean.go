@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+
+	"github.com/mongoose84/proser/filesystem"
+)
+
+// CleanAction describes what Clean did with a file from the manifest
+type CleanAction string
+
+const (
+	// CleanRemoved means the file was deleted
+	CleanRemoved CleanAction = "removed"
+	// CleanKeptModified means the file was kept because it changed since generation
+	CleanKeptModified CleanAction = "kept (modified)"
+	// CleanMissing means the file was already gone
+	CleanMissing CleanAction = "missing"
+)
+
+// CleanResult records the outcome for a single file
+type CleanResult struct {
+	Path   string
+	Action CleanAction
+}
+
+// errDirNotEmpty stops the walk in isEmptyDir as soon as an entry is found
+var errDirNotEmpty = errors.New("directory not empty")
+
+// Clean removes the files recorded in the manifest and prunes the directories proser
+// created that they leave empty. Files that differ from the generator output, including those with text
+// added outside their managed regions, are kept unless force is set.
+// Removed and missing files are dropped from the manifest.
+func Clean(fsys filesystem.FileSystem, targetPath string, manifest *Manifest, force bool) ([]CleanResult, error) {
+	paths := make([]string, 0, len(manifest.Files))
+	for relPath := range manifest.Files {
+		paths = append(paths, relPath)
+	}
+	sort.Strings(paths)
+
+	var results []CleanResult
+	dirs := make(map[string]bool)
+	for _, relPath := range paths {
+		fullPath := filepath.Join(targetPath, filepath.FromSlash(relPath))
+
+		data, err := fsys.ReadFile(fullPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			delete(manifest.Files, relPath)
+			results = append(results, CleanResult{Path: relPath, Action: CleanMissing})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", fullPath, err)
+		}
+
+		if !force && !manifest.Pristine(relPath, string(data)) {
+			results = append(results, CleanResult{Path: relPath, Action: CleanKeptModified})
+			continue
+		}
+
+		if err := fsys.Remove(fullPath); err != nil {
+			return nil, fmt.Errorf("failed to remove file %s: %w", fullPath, err)
+		}
+		delete(manifest.Files, relPath)
+		results = append(results, CleanResult{Path: relPath, Action: CleanRemoved})
+		dirs[filepath.Dir(fullPath)] = true
+	}
+
+	if err := manifest.PruneDirs(fsys, targetPath, dirs); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// isEmptyDir reports whether dir exists and has no entries
+func isEmptyDir(fsys filesystem.FileSystem, dir string) (bool, error) {
+	info, err := fsys.Stat(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to stat %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return false, nil
+	}
+
+	err = fsys.Walk(dir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if filepath.Clean(path) != dir {
+			return errDirNotEmpty
+		}
+		return nil
+	})
+	if errors.Is(err, errDirNotEmpty) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	return true, nil
+}
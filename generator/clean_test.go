@@ -0,0 +1,161 @@
+package generator
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mongoose84/proser/filesystem"
+)
+
+// staticGenerator produces a fixed set of files
+type staticGenerator map[string]string
+
+func (g staticGenerator) Name() string { return "static" }
+
+func (g staticGenerator) Generate(ctx GenerateContext) (map[string]string, error) {
+	return g, nil
+}
+
+func TestClean(t *testing.T) {
+	const target = "/project"
+	agents := "# Agents\n" + ManagedSection("overview", "generated overview")
+
+	tests := []struct {
+		name       string
+		edit       func(fsys filesystem.FileSystem)
+		update     bool // regenerate after the edit, merging the managed regions
+		force      bool
+		wantAction CleanAction
+		wantExists bool
+	}{
+		{
+			name:       "removes untouched file",
+			wantAction: CleanRemoved,
+		},
+		{
+			name: "keeps file edited inside a managed region",
+			edit: func(fsys filesystem.FileSystem) {
+				writeFile(t, fsys, target, "AGENTS.md", "# Agents\n"+ManagedSection("overview", "edited"))
+			},
+			wantAction: CleanKeptModified,
+			wantExists: true,
+		},
+		{
+			name: "keeps file with a section added outside the managed regions",
+			edit: func(fsys filesystem.FileSystem) {
+				writeFile(t, fsys, target, "AGENTS.md", agents+"\n## Team Notes\nhand-written\n")
+			},
+			update:     true,
+			wantAction: CleanKeptModified,
+			wantExists: true,
+		},
+		{
+			name: "force removes modified file",
+			edit: func(fsys filesystem.FileSystem) {
+				writeFile(t, fsys, target, "AGENTS.md", agents+"\n## Team Notes\nhand-written\n")
+			},
+			update:     true,
+			force:      true,
+			wantAction: CleanRemoved,
+		},
+		{
+			name: "reports missing file",
+			edit: func(fsys filesystem.FileSystem) {
+				if err := fsys.Remove(filepath.Join(target, "AGENTS.md")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantAction: CleanMissing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := filesystem.NewMemoryFileSystem()
+			if err := fsys.MkdirAll(target, 0755); err != nil {
+				t.Fatal(err)
+			}
+			writer := NewWriter(fsys)
+			writer.Manifest = NewManifest()
+			ctx := GenerateContext{TargetPath: target, FS: fsys}
+			gen := staticGenerator{"AGENTS.md": agents}
+
+			if _, err := writer.RunGenerator(gen, ctx); err != nil {
+				t.Fatal(err)
+			}
+			if tt.edit != nil {
+				tt.edit(fsys)
+			}
+			if tt.update {
+				if _, err := writer.RunGenerator(gen, ctx); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			results, err := Clean(fsys, target, writer.Manifest, tt.force)
+			if err != nil {
+				t.Fatalf("Clean() error = %v", err)
+			}
+			if len(results) != 1 || results[0].Action != tt.wantAction {
+				t.Fatalf("Clean() = %+v, want action %q", results, tt.wantAction)
+			}
+			_, err = fsys.Stat(filepath.Join(target, "AGENTS.md"))
+			if exists := err == nil; exists != tt.wantExists {
+				t.Errorf("AGENTS.md exists = %v, want %v", exists, tt.wantExists)
+			}
+			_, tracked := writer.Manifest.Lookup("AGENTS.md")
+			if tracked != tt.wantExists {
+				t.Errorf("AGENTS.md tracked = %v, want %v", tracked, tt.wantExists)
+			}
+		})
+	}
+}
+
+func TestCleanPrunesCreatedDirs(t *testing.T) {
+	const target = "/project"
+	fsys := filesystem.NewMemoryFileSystem()
+	// .github existed before proser ran; its subdirectories did not
+	if err := fsys.MkdirAll(filepath.Join(target, ".github"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writer := NewWriter(fsys)
+	writer.Manifest = NewManifest()
+	ctx := GenerateContext{TargetPath: target, FS: fsys}
+	gen := staticGenerator{
+		".github/instructions/backend.instructions.md": "backend\n",
+		".github/prompts/review.prompt.md":             "review\n",
+	}
+	if _, err := writer.RunGenerator(gen, ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := writer.Manifest.Dirs, []string{".github/instructions", ".github/prompts"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Dirs = %q, want %q", got, want)
+	}
+	writeFile(t, fsys, target, ".github/prompts/team.prompt.md", "ours\n")
+
+	if _, err := Clean(fsys, target, writer.Manifest, false); err != nil {
+		t.Fatal(err)
+	}
+	for dir, want := range map[string]bool{
+		".github":              true,  // existed before proser
+		".github/instructions": false, // created by proser and left empty
+		".github/prompts":      true,  // still holds a user file
+	} {
+		_, err := fsys.Stat(filepath.Join(target, dir))
+		if exists := err == nil; exists != want {
+			t.Errorf("%s exists = %v, want %v", dir, exists, want)
+		}
+	}
+	if got, want := writer.Manifest.Dirs, []string{".github/prompts"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Dirs after Clean = %q, want %q", got, want)
+	}
+}
+
+// writeFile writes a file below target, failing the test on error
+func writeFile(t *testing.T, fsys filesystem.FileSystem, target, relPath, content string) {
+	t.Helper()
+	if err := fsys.WriteFile(filepath.Join(target, relPath), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/mongoose84/proser/filesystem"
+	"github.com/mongoose84/proser/language"
+)
+
+// contextGroup is a kind of file a Context Loading section points to
+type contextGroup struct {
+	label      string
+	candidates []string // paths relative to the project root; "*" matches one segment, a trailing "/" a directory
+	limit      int      // how many existing candidates to link
+}
+
+// languageContextGroups returns the manifests and entry points worth reading for a language,
+// or the README alone when the language is not in the registry
+func languageContextGroups(lang *language.LanguageInfo) []contextGroup {
+	if lang == nil {
+		return []contextGroup{
+			{"project conventions", []string{"README.md"}, 1},
+		}
+	}
+	return []contextGroup{
+		{"dependencies", lang.ContextFiles, 1},
+		{"application structure", lang.EntryPoints, 3},
+	}
+}
+
+// writeContextLoading writes the links of groups whose targets exist in the project as a
+// single "Review ... before ..." sentence. Links are relative to .github/instructions/.
+func writeContextLoading(sb *strings.Builder, ctx GenerateContext, groups []contextGroup, before string) {
+	var candidates []string
+	for _, group := range groups {
+		candidates = append(candidates, group.candidates...)
+	}
+	matches := globContextPaths(ctx.FS, ctx.TargetPath, candidates)
+
+	var parts []string
+	for _, group := range groups {
+		paths := firstContextPaths(matches, group.candidates, group.limit)
+		if len(paths) == 0 {
+			continue
+		}
+		links := make([]string, len(paths))
+		for i, p := range paths {
+			links[i] = fmt.Sprintf("[%s](../../%s)", p, p)
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", group.label, strings.Join(links, ", ")))
+	}
+
+	sb.WriteString("## Context Loading\n")
+	switch len(parts) {
+	case 0:
+		sb.WriteString(fmt.Sprintf("Review the existing code %s.\n\n", before))
+	case 1:
+		sb.WriteString(fmt.Sprintf("Review %s %s.\n\n", parts[0], before))
+	default:
+		sb.WriteString(fmt.Sprintf("Review %s and\n%s %s.\n\n",
+			strings.Join(parts[:len(parts)-1], ", "), parts[len(parts)-1], before))
+	}
+}
+
+// firstContextPaths returns up to limit matches of candidates, in candidate order
+func firstContextPaths(matches map[string][]string, candidates []string, limit int) []string {
+	var found []string
+	for _, candidate := range candidates {
+		for _, p := range matches[candidate] {
+			if len(found) == limit {
+				return found
+			}
+			found = append(found, p)
+		}
+	}
+	return found
+}
+
+// globContextPaths expands every candidate against the files below root in a single walk.
+// Matches are keyed by candidate and in path order; directories keep their trailing slash.
+// Ignored paths are never returned.
+func globContextPaths(fsys filesystem.FileSystem, root string, candidates []string) map[string][]string {
+	matches := make(map[string][]string)
+	_ = filesystem.NewIgnoreWalker(fsys).Walk(root, func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return filepath.SkipDir
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil || rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		level := strings.Count(rel, "/") + 1
+
+		descend := false
+		for _, candidate := range candidates {
+			wantDir := strings.HasSuffix(candidate, "/")
+			segments := strings.Split(strings.TrimSuffix(candidate, "/"), "/")
+			if level > len(segments) {
+				continue
+			}
+			if matched, _ := path.Match(strings.Join(segments[:level], "/"), rel); !matched {
+				continue
+			}
+			switch {
+			case level < len(segments):
+				descend = descend || info.IsDir()
+			case info.IsDir() != wantDir:
+			case wantDir:
+				matches[candidate] = append(matches[candidate], rel+"/")
+			default:
+				matches[candidate] = append(matches[candidate], rel)
+			}
+		}
+
+		// Only descend into directories on the way to a pattern
+		if info.IsDir() && !descend {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return matches
+}
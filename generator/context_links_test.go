@@ -0,0 +1,39 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mongoose84/proser/filesystem"
+)
+
+func TestGlobContextPaths(t *testing.T) {
+	const target = "/project"
+	fsys := filesystem.NewMemoryFileSystem()
+	for _, rel := range []string{
+		"go.mod",
+		"main.go",
+		"cmd/api/main.go",
+		"cmd/worker/main.go",
+		"cmd/tool/tool.go",
+		"src/app.ts",
+		"cmd/legacy/main.go",
+	} {
+		writeFile(t, fsys, target, rel, "")
+	}
+	writeFile(t, fsys, target, ".gitignore", "cmd/legacy/\n")
+
+	got := globContextPaths(fsys, target, []string{"go.mod", "go.sum", "cmd/*/main.go", "src/", "main.go/"})
+	want := map[string][]string{
+		"go.mod":        {"go.mod"},
+		"cmd/*/main.go": {"cmd/api/main.go", "cmd/worker/main.go"},
+		"src/":          {"src/"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("globContextPaths() = %q, want %q", got, want)
+	}
+
+	if got, want := firstContextPaths(got, []string{"main.go", "cmd/*/main.go"}, 1), []string{"cmd/api/main.go"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("firstContextPaths() = %q, want %q", got, want)
+	}
+}
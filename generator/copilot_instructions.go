@@ -20,18 +20,24 @@ func (g *CopilotInstructionsGenerator) Generate(ctx GenerateContext) (map[string
 	cfg := ctx.Config
 
 	var sb strings.Builder
+	sb.WriteString(managedRegionsNotice)
 
-	sb.WriteString("# Global Repository Instructions\n\n")
+	writeManagedSection(&sb, "project-overview", func(sb *strings.Builder) {
+		sb.WriteString("# Global Repository Instructions\n\n")
 
-	sb.WriteString("## Project Overview\n")
-	if cfg.General.Description != "" {
-		sb.WriteString(cfg.General.Description + "\n\n")
-	} else {
-		sb.WriteString("This project follows PROSE framework conventions for AI-native development.\n\n")
-	}
+		sb.WriteString("## Project Overview\n")
+		if cfg.General.Description != "" {
+			sb.WriteString(cfg.General.Description + "\n\n")
+		} else {
+			sb.WriteString("This project follows PROSE framework conventions for AI-native development.\n\n")
+		}
+	})
 
 	// Technology stack overview
-	if cfg.HasFrontend() || cfg.HasBackend() {
+	writeManagedSection(&sb, "tech-stack", func(sb *strings.Builder) {
+		if !cfg.HasFrontend() && !cfg.HasBackend() {
+			return
+		}
 		sb.WriteString("## Technology Stack\n")
 		if cfg.HasBackend() {
 			sb.WriteString(fmt.Sprintf("- **Backend**: %s", cfg.Backend.Language))
@@ -54,30 +60,41 @@ func (g *CopilotInstructionsGenerator) Generate(ctx GenerateContext) (map[string
 			sb.WriteString(fmt.Sprintf("- **Testing**: %s\n", cfg.Testing.Framework))
 		}
 		sb.WriteString("\n")
-	}
+	})
 
-	if cfg.General.CodeStyle != "" {
-		sb.WriteString("## Code Style\n")
-		sb.WriteString(cfg.General.CodeStyle + "\n\n")
-	}
+	writeManagedSection(&sb, "code-style", func(sb *strings.Builder) {
+		if cfg.General.CodeStyle != "" {
+			sb.WriteString("## Code Style\n")
+			sb.WriteString(cfg.General.CodeStyle + "\n\n")
+		}
+	})
 
-	if cfg.HasBackend() && cfg.Backend.APIRules != "" {
-		sb.WriteString("## API Guidelines\n")
-		sb.WriteString(cfg.Backend.APIRules + "\n\n")
-	}
+	writeManagedSection(&sb, "api-guidelines", func(sb *strings.Builder) {
+		if cfg.HasBackend() && cfg.Backend.APIRules != "" {
+			sb.WriteString("## API Guidelines\n")
+			sb.WriteString(cfg.Backend.APIRules + "\n\n")
+		}
+	})
 
-	if cfg.General.Security != "" {
-		sb.WriteString("## Security Requirements\n")
-		sb.WriteString(cfg.General.Security + "\n\n")
-	}
+	writeManagedSection(&sb, "security", func(sb *strings.Builder) {
+		if cfg.General.Security != "" {
+			sb.WriteString("## Security Requirements\n")
+			sb.WriteString(cfg.General.Security + "\n\n")
+		}
+	})
 
-	if cfg.General.CustomRules != "" && cfg.General.CustomRules != "None" {
-		sb.WriteString("## Custom Project Rules\n")
-		sb.WriteString(cfg.General.CustomRules + "\n\n")
-	}
+	writeManagedSection(&sb, "custom-rules", func(sb *strings.Builder) {
+		if cfg.General.CustomRules != "" && cfg.General.CustomRules != "None" {
+			sb.WriteString("## Custom Project Rules\n")
+			sb.WriteString(cfg.General.CustomRules + "\n\n")
+		}
+	})
 
 	// Instructions Hierarchy for Progressive Disclosure
-	if cfg.HasBackend() || cfg.HasFrontend() || cfg.Testing.Framework != "" {
+	writeManagedSection(&sb, "instructions-hierarchy", func(sb *strings.Builder) {
+		if !cfg.HasBackend() && !cfg.HasFrontend() && cfg.Testing.Framework == "" {
+			return
+		}
 		sb.WriteString("## Instructions Hierarchy\n")
 		sb.WriteString("This file provides global context. Specialized instructions:\n")
 		if cfg.HasBackend() {
@@ -90,7 +107,7 @@ func (g *CopilotInstructionsGenerator) Generate(ctx GenerateContext) (map[string
 			sb.WriteString("- [Testing Guidelines](.github/instructions/testing.instructions.md)\n")
 		}
 		sb.WriteString("\n")
-	}
+	})
 
 	// Store the file with a relative path from target
 	relPath := filepath.Join(".github", "copilot-instructions.md")
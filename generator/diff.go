@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// noNewlineMarker follows a diff line that has no newline at the end of its file
+const noNewlineMarker = "\\ No newline at end of file\n"
+
+// diffOp is a single line-level edit operation
+type diffOp struct {
+	kind byte   // ' ', '-' or '+'
+	line string // including its newline, unless it is the unterminated last line
+}
+
+// UnifiedDiff returns a unified diff turning oldContent into newContent.
+// An empty string is returned when the contents are identical.
+func UnifiedDiff(path, oldContent, newContent string, oldExists bool) string {
+	if oldContent == newContent {
+		return ""
+	}
+
+	ops := diffLines(splitLines(oldContent), splitLines(newContent))
+
+	var sb strings.Builder
+	if oldExists {
+		sb.WriteString(fmt.Sprintf("--- a/%s\n", path))
+	} else {
+		sb.WriteString("--- /dev/null\n")
+	}
+	sb.WriteString(fmt.Sprintf("+++ b/%s\n", path))
+
+	for _, h := range buildHunks(ops) {
+		sb.WriteString(h)
+	}
+	return sb.String()
+}
+
+// splitLines splits content into lines that keep their newline, so a last line without
+// one differs from the same line with one, as in diff -u
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines computes a minimal edit script using the longest common subsequence
+func diffLines(a, b []string) []diffOp {
+	// lcs[i][j] holds the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
+
+// buildHunks groups edit operations into unified diff hunks with surrounding context
+func buildHunks(ops []diffOp) []string {
+	var hunks []string
+
+	idx := 0
+	for idx < len(ops) {
+		// Find the next change
+		for idx < len(ops) && ops[idx].kind == ' ' {
+			idx++
+		}
+		if idx == len(ops) {
+			break
+		}
+
+		start := idx - diffContext
+		if start < 0 {
+			start = 0
+		}
+
+		// Extend the hunk until a run of unchanged lines is long enough to split on
+		end := idx
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].kind == ' ' {
+				run++
+			}
+			if run == len(ops) || run-end > 2*diffContext {
+				end += min(diffContext, run-end)
+				break
+			}
+			end = run
+		}
+
+		hunks = append(hunks, formatHunk(ops, start, end))
+		idx = end
+	}
+
+	return hunks
+}
+
+// formatHunk renders ops[start:end] with its @@ header
+func formatHunk(ops []diffOp, start, end int) string {
+	// Line numbers are 1-based positions in the old and new files
+	oldLine, newLine := 1, 1
+	for _, op := range ops[:start] {
+		if op.kind != '+' {
+			oldLine++
+		}
+		if op.kind != '-' {
+			newLine++
+		}
+	}
+
+	var body strings.Builder
+	oldCount, newCount := 0, 0
+	for _, op := range ops[start:end] {
+		if op.kind != '+' {
+			oldCount++
+		}
+		if op.kind != '-' {
+			newCount++
+		}
+		body.WriteByte(op.kind)
+		body.WriteString(op.line)
+		if !strings.HasSuffix(op.line, "\n") {
+			body.WriteString("\n" + noNewlineMarker)
+		}
+	}
+
+	// An empty range starts at the line before it, per the unified diff format
+	if oldCount == 0 {
+		oldLine--
+	}
+	if newCount == 0 {
+		newLine--
+	}
+
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", oldLine, oldCount, newLine, newCount) + body.String()
+}
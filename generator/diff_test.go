@@ -0,0 +1,72 @@
+package generator
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name      string
+		old       string
+		new       string
+		oldExists bool
+		want      string
+	}{
+		{
+			name:      "identical",
+			old:       "a\nb\n",
+			new:       "a\nb\n",
+			oldExists: true,
+			want:      "",
+		},
+		{
+			name: "new file",
+			new:  "a\nb\n",
+			want: "--- /dev/null\n+++ b/f.md\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:      "changed line",
+			old:       "a\nb\nc\n",
+			new:       "a\nB\nc\n",
+			oldExists: true,
+			want:      "--- a/f.md\n+++ b/f.md\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name:      "distant changes make separate hunks",
+			old:       "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			new:       "one\n2\n3\n4\n5\n6\n7\n8\n9\nten\n",
+			oldExists: true,
+			want: "--- a/f.md\n+++ b/f.md\n" +
+				"@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n" +
+				"@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+ten\n",
+		},
+		{
+			name:      "newline added at end of file",
+			old:       "a\nb",
+			new:       "a\nb\n",
+			oldExists: true,
+			want:      "--- a/f.md\n+++ b/f.md\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+		{
+			name:      "newline removed at end of file",
+			old:       "a\nb\n",
+			new:       "a\nb",
+			oldExists: true,
+			want:      "--- a/f.md\n+++ b/f.md\n@@ -1,2 +1,2 @@\n a\n-b\n+b\n\\ No newline at end of file\n",
+		},
+		{
+			name:      "both sides without final newline",
+			old:       "a\nb",
+			new:       "a\nc",
+			oldExists: true,
+			want:      "--- a/f.md\n+++ b/f.md\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UnifiedDiff("f.md", tt.old, tt.new, tt.oldExists)
+			if got != tt.want {
+				t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
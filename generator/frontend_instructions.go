@@ -3,6 +3,8 @@ package generator
 import (
 	"fmt"
 	"strings"
+
+	"github.com/mongoose84/proser/language"
 )
 
 // FrontendInstructionsGenerator generates frontend-specific instructions
@@ -20,13 +22,21 @@ func (g *FrontendInstructionsGenerator) Generate(ctx GenerateContext) (map[strin
 	}
 
 	cfg := ctx.Config
-	lang := strings.ToLower(cfg.Frontend.Language)
+	languages, err := ctx.registry()
+	if err != nil {
+		return nil, err
+	}
+	lang, _ := languages.LookupLanguage(cfg.Frontend.Language) // nil for languages the registry does not know
+	var framework *language.FrameworkInfo
+	if cfg.Frontend.Framework != "" && cfg.Frontend.Framework != "Vanilla" {
+		framework, _ = languages.LookupFramework(cfg.Frontend.Framework)
+	}
 
 	var sb strings.Builder
 
 	// --- Frontmatter ---
 	sb.WriteString("---\n")
-	sb.WriteString(fmt.Sprintf("applyTo: \"%s\"\n", frontendApplyTo(lang, cfg.Frontend.Framework)))
+	sb.WriteString(fmt.Sprintf("applyTo: \"%s\"\n", frontendApplyTo(lang, framework)))
 	sb.WriteString(fmt.Sprintf("description: \"%s development guidelines with context engineering\"\n",
 		cfg.Frontend.Language))
 	sb.WriteString("---\n")
@@ -35,46 +45,30 @@ func (g *FrontendInstructionsGenerator) Generate(ctx GenerateContext) (map[strin
 	sb.WriteString("Inherits from [global instructions](../copilot-instructions.md).\n\n")
 
 	// --- Context Loading ---
-	sb.WriteString("## Context Loading\n")
-	sb.WriteString("Review [project conventions](../../README.md)")
+	components := "component patterns"
 	if cfg.Frontend.Framework != "" && cfg.Frontend.Framework != "Vanilla" {
-		sb.WriteString(fmt.Sprintf(" and\n[%s component patterns](../../src/) before starting.\n\n",
-			cfg.Frontend.Framework))
-	} else {
-		sb.WriteString(" and\n[component patterns](../../src/) before starting.\n\n")
+		components = cfg.Frontend.Framework + " component patterns"
 	}
+	writeContextLoading(&sb, ctx, []contextGroup{
+		{"project conventions", []string{"README.md"}, 1},
+		{components, []string{"src/components/", "*/src/components/", "src/lib/components/", "components/", "src/app/", "app/", "src/"}, 1},
+	}, "before starting")
 
 	// --- Deterministic Requirements ---
 	sb.WriteString("## Deterministic Requirements\n")
 
 	// Language-specific requirements
-	switch lang {
-	case "typescript", "ts":
-		sb.WriteString("- Use strict TypeScript configuration\n")
-		sb.WriteString("- Define proper type definitions and interfaces\n")
-		sb.WriteString("- Avoid `any` — leverage the type system for safety\n")
-	case "javascript", "js":
-		sb.WriteString("- Follow modern JavaScript best practices (ES6+)\n")
-		sb.WriteString("- Use proper module imports/exports\n")
-	default:
+	if lang != nil {
+		writeBullets(&sb, lang.Guidelines)
+	} else {
 		sb.WriteString(fmt.Sprintf("- Follow %s best practices and conventions\n", cfg.Frontend.Language))
 	}
 
 	// Framework-specific requirements
-	if cfg.Frontend.Framework != "" && cfg.Frontend.Framework != "Vanilla" {
-		switch strings.ToLower(cfg.Frontend.Framework) {
-		case "react":
-			sb.WriteString("- Prefer functional components with hooks\n")
-			sb.WriteString("- Implement error boundaries for React components\n")
-		case "vue":
-			sb.WriteString("- Use Vue 3 Composition API\n")
-			sb.WriteString("- Follow single-file component structure\n")
-		case "angular":
-			sb.WriteString("- Follow the Angular style guide\n")
-			sb.WriteString("- Use dependency injection and RxJS observables\n")
-		default:
-			sb.WriteString(fmt.Sprintf("- Follow %s patterns and conventions\n", cfg.Frontend.Framework))
-		}
+	if framework != nil {
+		writeBullets(&sb, framework.Guidelines)
+	} else if cfg.Frontend.Framework != "" && cfg.Frontend.Framework != "Vanilla" {
+		sb.WriteString(fmt.Sprintf("- Follow %s patterns and conventions\n", cfg.Frontend.Framework))
 	}
 
 	// Universal frontend requirements
@@ -89,17 +83,16 @@ func (g *FrontendInstructionsGenerator) Generate(ctx GenerateContext) (map[strin
 	}
 	sb.WriteString("\n")
 
+	// --- Best Practices ---
+	writeBestPractices(&sb, lang)
+
 	// --- Structured Output ---
 	sb.WriteString("## Structured Output\n")
 	sb.WriteString("Generate code with:\n")
 
-	switch lang {
-	case "typescript", "ts":
-		sb.WriteString("- [ ] JSDoc comments for all public APIs\n")
-		sb.WriteString("- [ ] Type exports in appropriate index files\n")
-	case "javascript", "js":
-		sb.WriteString("- [ ] JSDoc comments for all public APIs\n")
-	default:
+	if lang != nil {
+		writeChecklist(&sb, lang.OutputChecklist)
+	} else {
 		sb.WriteString("- [ ] Documentation and type annotations for public APIs\n")
 	}
 
@@ -112,21 +105,20 @@ func (g *FrontendInstructionsGenerator) Generate(ctx GenerateContext) (map[strin
 	}, nil
 }
 
-// frontendApplyTo returns the applyTo glob for a given frontend language/framework
-func frontendApplyTo(lang, framework string) string {
-	fw := strings.ToLower(framework)
-	switch {
-	case fw == "vue":
-		return "**/*.{vue,js,ts,css,scss,sass,less}"
-	case fw == "angular":
-		return "**/*.{ts,html,css,scss,sass,less}"
-	}
-	switch lang {
-	case "typescript", "ts":
-		return "**/*.{ts,tsx,css,scss,sass,less}"
-	case "javascript", "js":
-		return "**/*.{js,jsx,css,scss,sass,less}"
-	default:
+// frontendApplyTo returns the applyTo glob for the source files and stylesheets of a
+// frontend language and framework
+func frontendApplyTo(lang *language.LanguageInfo, framework *language.FrameworkInfo) string {
+	if lang == nil {
 		return "**/*.{js,jsx,ts,tsx,css,html,vue,scss,sass,less}"
 	}
+	var extensions []string
+	if framework != nil {
+		extensions = append(extensions, framework.FileExtensions...)
+	}
+	extensions = append(extensions, lang.FileExtensions...)
+	extensions = append(extensions, lang.StyleExtensions...)
+	if framework != nil {
+		extensions = append(extensions, framework.StyleExtensions...)
+	}
+	return extensionGlob(extensions)
 }
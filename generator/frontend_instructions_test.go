@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/mongoose84/proser/language"
+)
+
+func TestFrontendApplyTo(t *testing.T) {
+	registry := language.NewDefaultRegistry()
+
+	tests := []struct {
+		name      string
+		language  string
+		framework string
+		want      string
+	}{
+		{
+			name: "unknown language",
+			want: "**/*.{js,jsx,ts,tsx,css,html,vue,scss,sass,less}",
+		},
+		{
+			name:     "javascript with stylesheets",
+			language: "JavaScript",
+			want:     "**/*.{js,jsx,mjs,cjs,css,scss,sass,less}",
+		},
+		{
+			name:      "framework extensions come first",
+			language:  "TypeScript",
+			framework: "Angular",
+			want:      "**/*.{html,ts,tsx,css,scss,sass,less}",
+		},
+		{
+			name:      "flutter has no stylesheets",
+			language:  "Dart",
+			framework: "Flutter",
+			want:      "**/*.dart",
+		},
+		{
+			name:      "swiftui has no stylesheets",
+			language:  "Swift",
+			framework: "SwiftUI",
+			want:      "**/*.swift",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang, _ := registry.LookupLanguage(tt.language)
+			framework, _ := registry.LookupFramework(tt.framework)
+			if got := frontendApplyTo(lang, framework); got != tt.want {
+				t.Errorf("frontendApplyTo() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package generator
 
 import (
+	"sync"
+
 	"github.com/mongoose84/proser/config"
 	"github.com/mongoose84/proser/filesystem"
+	"github.com/mongoose84/proser/language"
 )
 
 // GenerateContext provides generators with everything they need
@@ -10,6 +13,18 @@ type GenerateContext struct {
 	Config     config.ProjectConfig
 	TargetPath string // absolute path to the target project root
 	FS         filesystem.FileSystem
+	Languages  *language.Registry // language and framework guidance; the built-in registry if nil
+}
+
+// builtinLanguages loads the built-in registry once, for contexts without a registry
+var builtinLanguages = sync.OnceValues(language.LoadDefaultRegistry)
+
+// registry returns the registry to describe languages with
+func (ctx GenerateContext) registry() (*language.Registry, error) {
+	if ctx.Languages != nil {
+		return ctx.Languages, nil
+	}
+	return builtinLanguages()
 }
 
 // Generator interface for all PROSE file type generators
@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/mongoose84/proser/language"
+)
+
+// writeBullets writes registry lines as a Markdown list
+func writeBullets(sb *strings.Builder, lines []string) {
+	for _, line := range lines {
+		sb.WriteString("- " + line + "\n")
+	}
+}
+
+// writeChecklist writes registry lines as a Markdown task list
+func writeChecklist(sb *strings.Builder, lines []string) {
+	for _, line := range lines {
+		sb.WriteString("- [ ] " + line + "\n")
+	}
+}
+
+// writeBestPractices writes the Best Practices section of a known language
+func writeBestPractices(sb *strings.Builder, lang *language.LanguageInfo) {
+	if lang == nil || len(lang.BestPractices) == 0 {
+		return
+	}
+	sb.WriteString("## Best Practices\n")
+	writeBullets(sb, lang.BestPractices)
+	sb.WriteString("\n")
+}
+
+// extensionGlob returns an applyTo glob matching files with any of the extensions,
+// e.g. "**/*.go" or "**/*.{ts,tsx}"
+func extensionGlob(extensions []string) string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, ext := range extensions {
+		name := strings.TrimPrefix(ext, ".")
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if len(names) == 1 {
+		return "**/*." + names[0]
+	}
+	return "**/*.{" + strings.Join(names, ",") + "}"
+}
+
+// joinGlobs combines applyTo globs into one comma-separated value, dropping duplicates
+func joinGlobs(globs []string) string {
+	var unique []string
+	seen := make(map[string]bool)
+	for _, glob := range globs {
+		if !seen[glob] {
+			seen[glob] = true
+			unique = append(unique, glob)
+		}
+	}
+	return strings.Join(unique, ",")
+}
@@ -0,0 +1,268 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mongoose84/proser/config"
+	"github.com/mongoose84/proser/filesystem"
+)
+
+// ManifestPath is where the manifest is stored, relative to the target project root
+const ManifestPath = ".proser/manifest.json"
+
+// manifestVersion is the current manifest format version.
+// Version 2 added GeneratedHash; version 1 entries only have ContentHash.
+// Version 3 added Dirs; older manifests let clean remove no directories.
+const manifestVersion = 3
+
+// ManifestEntry records how a single file was generated
+type ManifestEntry struct {
+	Generator     string `json:"generator"`
+	ConfigHash    string `json:"config_hash"`
+	GeneratedHash string `json:"generated_hash"` // generator output, before managed regions were merged
+	ContentHash   string `json:"content_hash"`   // file as written, with text kept outside managed regions
+}
+
+// Manifest records every file proser generated, keyed by path relative to the target
+type Manifest struct {
+	Version int                      `json:"version"`
+	Files   map[string]ManifestEntry `json:"files"`
+	Dirs    []string                 `json:"dirs,omitempty"` // directories proser created, sorted
+}
+
+// DriftStatus describes how a generated file changed after proser wrote it
+type DriftStatus string
+
+const (
+	// DriftModified means the file was edited since it was generated
+	DriftModified DriftStatus = "modified"
+	// DriftDeleted means the file was removed since it was generated
+	DriftDeleted DriftStatus = "deleted"
+	// DriftConfigChanged means the file is untouched but was generated from another configuration
+	DriftConfigChanged DriftStatus = "config changed"
+)
+
+// Drift is a generated file whose on-disk state no longer matches the manifest
+type Drift struct {
+	Path      string
+	Generator string
+	Status    DriftStatus
+}
+
+// NewManifest creates an empty manifest
+func NewManifest() *Manifest {
+	return &Manifest{
+		Version: manifestVersion,
+		Files:   make(map[string]ManifestEntry),
+	}
+}
+
+// LoadManifest reads the manifest from the target directory.
+// A missing manifest yields an empty one, as for a project never generated before.
+func LoadManifest(fsys filesystem.FileSystem, targetPath string) (*Manifest, error) {
+	path := filepath.Join(targetPath, ManifestPath)
+	data, err := fsys.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewManifest(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", path, err)
+	}
+
+	m := NewManifest()
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	if m.Files == nil {
+		m.Files = make(map[string]ManifestEntry)
+	}
+	m.Version = manifestVersion
+	return m, nil
+}
+
+// Save writes the manifest to the target directory
+func (m *Manifest) Save(fsys filesystem.FileSystem, targetPath string) error {
+	path := filepath.Join(targetPath, ManifestPath)
+	if _, err := fsys.Stat(filepath.Dir(path)); errors.Is(err, fs.ErrNotExist) {
+		m.RecordDir(filepath.Dir(ManifestPath))
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	if err := fsys.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+	}
+	if err := fsys.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write manifest %s: %w", path, err)
+	}
+	return nil
+}
+
+// Record stores the entry for a file written by a generator. generated is the generator's
+// output and written the content on disk, which differ when managed regions were merged
+// into a file with text of its own.
+func (m *Manifest) Record(relPath, generatorName, configHash, generated, written string) {
+	m.Files[filepath.ToSlash(relPath)] = ManifestEntry{
+		Generator:     generatorName,
+		ConfigHash:    configHash,
+		GeneratedHash: HashContent(generated),
+		ContentHash:   HashContent(written),
+	}
+}
+
+// RecordDir notes that proser created a directory, given relative to the target
+func (m *Manifest) RecordDir(relDir string) {
+	relDir = filepath.ToSlash(relDir)
+	i := sort.SearchStrings(m.Dirs, relDir)
+	if i < len(m.Dirs) && m.Dirs[i] == relDir {
+		return
+	}
+	m.Dirs = append(m.Dirs[:i], append([]string{relDir}, m.Dirs[i:]...)...)
+}
+
+// PruneDirs removes each of dirs and its ancestors below targetPath while they are empty.
+// It stops at the first directory proser did not create, so directories that existed
+// before proser ran are kept even when empty. Removed directories are forgotten.
+func (m *Manifest) PruneDirs(fsys filesystem.FileSystem, targetPath string, dirs map[string]bool) error {
+	root := filepath.Clean(targetPath)
+	created := make(map[string]bool, len(m.Dirs))
+	for _, relDir := range m.Dirs {
+		created[relDir] = true
+	}
+
+	// Visit the deepest directories first so parents are empty by the time we reach them
+	sorted := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sorted = append(sorted, filepath.Clean(dir))
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return strings.Count(sorted[i], string(filepath.Separator)) > strings.Count(sorted[j], string(filepath.Separator))
+	})
+
+	for _, dir := range sorted {
+		for dir != root && strings.HasPrefix(dir, root+string(filepath.Separator)) {
+			rel, err := filepath.Rel(root, dir)
+			if err != nil || !created[filepath.ToSlash(rel)] {
+				break
+			}
+			empty, err := isEmptyDir(fsys, dir)
+			if err != nil {
+				return err
+			}
+			if !empty {
+				break
+			}
+			if err := fsys.Remove(dir); err != nil {
+				return fmt.Errorf("failed to remove directory %s: %w", dir, err)
+			}
+			delete(created, filepath.ToSlash(rel))
+			dir = filepath.Dir(dir)
+		}
+	}
+
+	m.Dirs = m.Dirs[:0]
+	for relDir := range created {
+		m.Dirs = append(m.Dirs, relDir)
+	}
+	sort.Strings(m.Dirs)
+	return nil
+}
+
+// Retain removes the entries of files not in keep and returns their paths, sorted.
+// A run records every file its generators produce, so the rest are no longer generated.
+func (m *Manifest) Retain(keep map[string]bool) []string {
+	var removed []string
+	for relPath := range m.Files {
+		if !keep[relPath] {
+			removed = append(removed, relPath)
+			delete(m.Files, relPath)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// Lookup returns the entry for a file, if proser generated it
+func (m *Manifest) Lookup(relPath string) (ManifestEntry, bool) {
+	entry, ok := m.Files[filepath.ToSlash(relPath)]
+	return entry, ok
+}
+
+// Unmodified reports whether content is exactly what proser last wrote to relPath,
+// including any text it kept outside managed regions
+func (m *Manifest) Unmodified(relPath, content string) bool {
+	entry, ok := m.Lookup(relPath)
+	return ok && entry.ContentHash == HashContent(content)
+}
+
+// Pristine reports whether content is exactly what the generator produced for relPath,
+// with no hand-written text anywhere in the file
+func (m *Manifest) Pristine(relPath, content string) bool {
+	entry, ok := m.Lookup(relPath)
+	return ok && entry.generatedHash() == HashContent(content)
+}
+
+// generatedHash returns the hash of the generator output, which version 1 manifests
+// only recorded as the content hash
+func (e ManifestEntry) generatedHash() string {
+	if e.GeneratedHash == "" {
+		return e.ContentHash
+	}
+	return e.GeneratedHash
+}
+
+// Drift compares the manifest with the filesystem and returns files that were modified
+// or deleted since proser generated them, sorted by path. Text added outside managed
+// regions counts as a modification. Untouched files generated from a configuration
+// other than configHash are reported as DriftConfigChanged.
+func (m *Manifest) Drift(fsys filesystem.FileSystem, targetPath, configHash string) ([]Drift, error) {
+	paths := make([]string, 0, len(m.Files))
+	for relPath := range m.Files {
+		paths = append(paths, relPath)
+	}
+	sort.Strings(paths)
+
+	var drift []Drift
+	for _, relPath := range paths {
+		entry := m.Files[relPath]
+		fullPath := filepath.Join(targetPath, filepath.FromSlash(relPath))
+
+		data, err := fsys.ReadFile(fullPath)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			drift = append(drift, Drift{Path: relPath, Generator: entry.Generator, Status: DriftDeleted})
+		case err != nil:
+			return nil, fmt.Errorf("failed to read file %s: %w", fullPath, err)
+		case HashContent(string(data)) != entry.generatedHash():
+			drift = append(drift, Drift{Path: relPath, Generator: entry.Generator, Status: DriftModified})
+		case entry.ConfigHash != configHash:
+			drift = append(drift, Drift{Path: relPath, Generator: entry.Generator, Status: DriftConfigChanged})
+		}
+	}
+	return drift, nil
+}
+
+// HashContent returns the content hash stored in the manifest
+func HashContent(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// HashConfig returns a stable hash of the configuration a file was generated from
+func HashConfig(cfg config.ProjectConfig) string {
+	// ProjectConfig only holds plain values, so encoding cannot fail
+	data, _ := json.Marshal(cfg)
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
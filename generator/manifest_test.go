@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mongoose84/proser/filesystem"
+)
+
+func TestManifestDrift(t *testing.T) {
+	const target = "/project"
+	generated := "# Agents\n" + ManagedSection("overview", "generated")
+	withNotes := generated + "\n## Team Notes\n"
+
+	tests := []struct {
+		name       string
+		entry      ManifestEntry
+		onDisk     *string
+		configHash string
+		want       DriftStatus // empty for no drift
+	}{
+		{
+			name:       "untouched",
+			entry:      ManifestEntry{ConfigHash: "cfg", GeneratedHash: HashContent(generated), ContentHash: HashContent(generated)},
+			onDisk:     &generated,
+			configHash: "cfg",
+		},
+		{
+			name:       "deleted",
+			entry:      ManifestEntry{ConfigHash: "cfg", GeneratedHash: HashContent(generated), ContentHash: HashContent(generated)},
+			configHash: "cfg",
+			want:       DriftDeleted,
+		},
+		{
+			name:       "edited since written",
+			entry:      ManifestEntry{ConfigHash: "cfg", GeneratedHash: HashContent(generated), ContentHash: HashContent(generated)},
+			onDisk:     &withNotes,
+			configHash: "cfg",
+			want:       DriftModified,
+		},
+		{
+			name:       "hand-written text kept by an earlier merge",
+			entry:      ManifestEntry{ConfigHash: "cfg", GeneratedHash: HashContent(generated), ContentHash: HashContent(withNotes)},
+			onDisk:     &withNotes,
+			configHash: "cfg",
+			want:       DriftModified,
+		},
+		{
+			name:       "generated from another configuration",
+			entry:      ManifestEntry{ConfigHash: "old", GeneratedHash: HashContent(generated), ContentHash: HashContent(generated)},
+			onDisk:     &generated,
+			configHash: "cfg",
+			want:       DriftConfigChanged,
+		},
+		{
+			name:       "version 1 entry without generated hash",
+			entry:      ManifestEntry{ConfigHash: "cfg", ContentHash: HashContent(generated)},
+			onDisk:     &generated,
+			configHash: "cfg",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := filesystem.NewMemoryFileSystem()
+			if err := fsys.MkdirAll(target, 0755); err != nil {
+				t.Fatal(err)
+			}
+			if tt.onDisk != nil {
+				writeFile(t, fsys, target, "AGENTS.md", *tt.onDisk)
+			}
+			m := NewManifest()
+			m.Files["AGENTS.md"] = tt.entry
+
+			drift, err := m.Drift(fsys, target, tt.configHash)
+			if err != nil {
+				t.Fatalf("Drift() error = %v", err)
+			}
+			var got DriftStatus
+			if len(drift) > 0 {
+				got = drift[0].Status
+			}
+			if len(drift) > 1 || got != tt.want {
+				t.Errorf("Drift() = %+v, want status %q", drift, tt.want)
+			}
+		})
+	}
+}
+
+func TestManifestRetain(t *testing.T) {
+	m := NewManifest()
+	for _, p := range []string{"AGENTS.md", "b/old.md", "a/old.md"} {
+		m.Record(p, "static", "cfg", "x", "x")
+	}
+
+	removed := m.Retain(map[string]bool{"AGENTS.md": true})
+	if want := []string{"a/old.md", "b/old.md"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("Retain() = %v, want %v", removed, want)
+	}
+	if len(m.Files) != 1 {
+		t.Errorf("Files = %v, want only AGENTS.md", m.Files)
+	}
+}
+
+func TestManifestSaveLoad(t *testing.T) {
+	const target = "/project"
+	fsys := filesystem.NewMemoryFileSystem()
+	m := NewManifest()
+	m.Record(filepath.Join("docs", "a.md"), "static", "cfg", "generated", "written")
+	if err := m.Save(fsys, target); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadManifest(fsys, target)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded, m) {
+		t.Errorf("LoadManifest() = %+v, want %+v", loaded, m)
+	}
+	if !loaded.Pristine("docs/a.md", "generated") || !loaded.Unmodified("docs/a.md", "written") {
+		t.Errorf("loaded entry does not match the recorded hashes: %+v", loaded.Files)
+	}
+}
@@ -0,0 +1,280 @@
+package generator
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mongoose84/proser/config"
+	"github.com/mongoose84/proser/filesystem"
+)
+
+// NestedAgentsMdGenerator writes a scoped AGENTS.md into each module directory below the
+// project root: Go packages, workspace packages with their own manifest and service folders.
+// It is opt-in through StructureConfig.NestedAgents; every scoped file links back to the
+// root AGENTS.md, which keeps the project-wide guidance.
+type NestedAgentsMdGenerator struct{}
+
+// Name returns the generator name.
+func (g *NestedAgentsMdGenerator) Name() string {
+	return "nested-agents-md"
+}
+
+// moduleManifests identify a directory as a package of its own, by kind
+var moduleManifests = []struct {
+	file string // base name, may be a glob
+	kind string
+}{
+	{"go.mod", "Go module"},
+	{"package.json", "Node.js package"},
+	{"pyproject.toml", "Python package"},
+	{"setup.py", "Python package"},
+	{"Cargo.toml", "Rust crate"},
+	{"pom.xml", "Java module"},
+	{"build.gradle", "Java module"},
+	{"build.gradle.kts", "Java module"},
+	{"*.csproj", ".NET project"},
+	{"build.sbt", "Scala project"},
+	{"composer.json", "PHP package"},
+	{"Gemfile", "Ruby project"},
+	{"*.gemspec", "Ruby gem"},
+	{"mix.exs", "Elixir project"},
+	{"Package.swift", "Swift package"},
+	{"pubspec.yaml", "Dart package"},
+	{"CMakeLists.txt", "CMake project"},
+}
+
+// moduleDir is a directory that gets a scoped AGENTS.md
+type moduleDir struct {
+	rel  string // slash-separated path relative to the project root
+	kind string // e.g. "Go package" or "Node.js package"
+	name string // package name, if the manifest or source declares one
+}
+
+// Generate creates an AGENTS.md for every module directory when nested files are enabled.
+func (g *NestedAgentsMdGenerator) Generate(ctx GenerateContext) (map[string]string, error) {
+	files := make(map[string]string)
+	if !ctx.Config.Structure.NestedAgents {
+		return files, nil
+	}
+
+	modules, err := findModuleDirs(ctx.FS, ctx.TargetPath, ctx.Config.Structure)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, module := range modules {
+		parent := ""
+		for _, candidate := range modules[:i] {
+			if strings.HasPrefix(module.rel, candidate.rel+"/") {
+				parent = candidate.rel
+			}
+		}
+		content, err := g.generateModule(ctx, module, parent)
+		if err != nil {
+			return nil, err
+		}
+		files[path.Join(module.rel, "AGENTS.md")] = content
+	}
+	return files, nil
+}
+
+// generateModule renders the scoped AGENTS.md of one module directory
+func (g *NestedAgentsMdGenerator) generateModule(ctx GenerateContext, module moduleDir, parent string) (string, error) {
+	dir := filepath.Join(ctx.TargetPath, filepath.FromSlash(module.rel))
+	tree, err := scanRepositoryTree(ctx.FS, ctx.TargetPath, dir, 1)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(managedRegionsNotice)
+
+	writeManagedSection(&sb, "header", func(sb *strings.Builder) {
+		sb.WriteString(fmt.Sprintf("# %s\n\n", module.rel))
+		scope := module.kind
+		if module.name != "" {
+			scope += fmt.Sprintf(" `%s`", module.name)
+		}
+		sb.WriteString(fmt.Sprintf("**Scope**: %s. This guide applies to `%s/` and everything below it.\n\n", scope, module.rel))
+	})
+
+	writeManagedSection(&sb, "hierarchy", func(sb *strings.Builder) {
+		sb.WriteString("## Inherited Guidance\n\n")
+		sb.WriteString(fmt.Sprintf("- Project-wide guidelines, tech stack and agent boundaries: [root AGENTS.md](%s)\n", relativeLink(module.rel, "AGENTS.md")))
+		if parent != "" {
+			sb.WriteString(fmt.Sprintf("- Enclosing module: [%s/AGENTS.md](%s)\n", parent, relativeLink(module.rel, parent+"/AGENTS.md")))
+		}
+		sb.WriteString("- Rules here only add to or narrow the inherited guidance\n\n")
+	})
+
+	writeManagedSection(&sb, "contents", func(sb *strings.Builder) {
+		sb.WriteString("## Contents\n\n")
+		renderTree(sb, path.Base(module.rel), tree)
+	})
+
+	writeManagedSection(&sb, "common-tasks", func(sb *strings.Builder) {
+		tasks, where := moduleTasks(ctx.FS, dir, module)
+		if len(tasks) == 0 {
+			return
+		}
+		sb.WriteString("## Common Tasks\n\n")
+		sb.WriteString(fmt.Sprintf("Run from %s:\n\n", where))
+		writeTaskTable(sb, tasks)
+	})
+
+	return sb.String(), nil
+}
+
+// moduleTasks returns the commands of a module and where to run them. Go packages are
+// built and tested from the project root; other modules define their own tasks.
+func moduleTasks(fsys filesystem.FileSystem, dir string, module moduleDir) ([]commonTask, string) {
+	if module.kind != "Go package" && module.kind != "Go command" {
+		return discoverTasks(fsys, dir), fmt.Sprintf("`%s/`", module.rel)
+	}
+
+	tasks := []commonTask{
+		{"Build", fmt.Sprintf("go build ./%s/...", module.rel), "Go conventions"},
+		{"Test", fmt.Sprintf("go test ./%s/...", module.rel), "Go conventions"},
+		{"Lint", fmt.Sprintf("go vet ./%s/...", module.rel), "Go conventions"},
+	}
+	if module.kind == "Go command" {
+		tasks = append(tasks, commonTask{"Run", "go run ./" + module.rel, "Go conventions"})
+	}
+	return tasks, "the project root"
+}
+
+// findModuleDirs walks the target up to the configured depth and returns the module
+// directories in path order. Hidden, dependency and build output directories, ignored
+// paths and directories matching an exclude glob are skipped.
+func findModuleDirs(fsys filesystem.FileSystem, root string, structure config.StructureConfig) ([]moduleDir, error) {
+	depth := structure.NestedDepth
+	if depth < 1 {
+		depth = config.DefaultNestedAgentsDepth
+	}
+
+	files := make(map[string][]string) // directory → file names
+	var dirs []string
+	err := filesystem.NewIgnoreWalker(fsys).Walk(root, func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil || rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		name := info.Name()
+
+		if info.IsDir() {
+			if strings.HasPrefix(name, ".") || treeSkipDirs[name] || strings.Count(rel, "/") >= depth || excluded(rel, structure.NestedExclude) {
+				return filepath.SkipDir
+			}
+			dirs = append(dirs, rel)
+			return nil
+		}
+		files[path.Dir(rel)] = append(files[path.Dir(rel)], name)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+
+	sort.Strings(dirs)
+	var modules []moduleDir
+	for _, rel := range dirs {
+		dir := filepath.Join(root, filepath.FromSlash(rel))
+		if module, ok := classifyModule(fsys, dir, rel, files[rel]); ok {
+			modules = append(modules, module)
+		}
+	}
+	return modules, nil
+}
+
+// classifyModule decides whether a directory is a module boundary from the files it contains
+func classifyModule(fsys filesystem.FileSystem, dir, rel string, names []string) (moduleDir, bool) {
+	for _, manifest := range moduleManifests {
+		for _, name := range names {
+			if matched, _ := path.Match(manifest.file, name); !matched {
+				continue
+			}
+			module := moduleDir{rel: rel, kind: manifest.kind}
+			if name == "package.json" {
+				module.name = packageName(fsys, filepath.Join(dir, name))
+			}
+			return module, true
+		}
+	}
+
+	for _, name := range names {
+		if name == "Dockerfile" || name == "Procfile" {
+			return moduleDir{rel: rel, kind: "Service"}, true
+		}
+	}
+
+	for _, name := range names {
+		if strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+			pkg := goPackageName(fsys, filepath.Join(dir, name))
+			if pkg == "main" {
+				return moduleDir{rel: rel, kind: "Go command", name: path.Base(rel)}, true
+			}
+			return moduleDir{rel: rel, kind: "Go package", name: pkg}, true
+		}
+	}
+	return moduleDir{}, false
+}
+
+// excluded reports whether rel or its base name matches one of the exclude globs
+func excluded(rel string, patterns []string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.Trim(pattern, "/")
+		if matched, _ := path.Match(pattern, rel); matched {
+			return true
+		}
+		if matched, _ := path.Match(pattern, path.Base(rel)); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// packageName returns the name field of a package.json, or "" if it cannot be read
+func packageName(fsys filesystem.FileSystem, file string) string {
+	data, err := fsys.ReadFile(file)
+	if err != nil {
+		return ""
+	}
+	var pkg struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return ""
+	}
+	return pkg.Name
+}
+
+// goPackageName returns the package clause of a Go source file, or "" if there is none
+func goPackageName(fsys filesystem.FileSystem, file string) string {
+	data, err := fsys.ReadFile(file)
+	if err != nil {
+		return ""
+	}
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "package" {
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// relativeLink returns the link from a file in dir to target, both relative to the project root
+func relativeLink(dir, target string) string {
+	return strings.Repeat("../", strings.Count(dir, "/")+1) + target
+}
@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ConflictPolicy decides what the writer does when a generated file would
+// replace different content that already exists on disk
+type ConflictPolicy string
+
+const (
+	// PolicyOverwrite replaces the existing file
+	PolicyOverwrite ConflictPolicy = "overwrite"
+	// PolicySkip keeps the existing file untouched
+	PolicySkip ConflictPolicy = "skip"
+	// PolicyBackup copies the existing file aside before replacing it
+	PolicyBackup ConflictPolicy = "backup"
+	// PolicyPrompt asks per file through the writer's InputCollector
+	PolicyPrompt ConflictPolicy = "prompt"
+)
+
+// conflictPolicies lists all valid policies
+var conflictPolicies = []ConflictPolicy{PolicyOverwrite, PolicySkip, PolicyBackup, PolicyPrompt}
+
+// ParseConflictPolicy converts a user-supplied name into a ConflictPolicy
+func ParseConflictPolicy(name string) (ConflictPolicy, error) {
+	normalized := ConflictPolicy(strings.ToLower(strings.TrimSpace(name)))
+	for _, p := range conflictPolicies {
+		if p == normalized {
+			return p, nil
+		}
+	}
+
+	names := make([]string, len(conflictPolicies))
+	for i, p := range conflictPolicies {
+		names[i] = string(p)
+	}
+	return "", fmt.Errorf("unknown conflict policy %q (expected one of: %s)", name, strings.Join(names, ", "))
+}
+
+// ParseGeneratorPolicies parses comma-separated generator=policy pairs,
+// e.g. "backend-instructions=skip,agents-md=backup"
+func ParseGeneratorPolicies(specs []string) (map[string]ConflictPolicy, error) {
+	policies := make(map[string]ConflictPolicy)
+	for _, spec := range specs {
+		for _, pair := range strings.Split(spec, ",") {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			name, value, found := strings.Cut(pair, "=")
+			if !found || strings.TrimSpace(name) == "" {
+				return nil, fmt.Errorf("invalid generator policy %q (expected generator=policy)", pair)
+			}
+			policy, err := ParseConflictPolicy(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid generator policy for %s: %w", name, err)
+			}
+			policies[strings.TrimSpace(name)] = policy
+		}
+	}
+	return policies, nil
+}
+
+// ValidateGeneratorNames returns an error if a per-generator policy names an unknown generator
+func ValidateGeneratorNames(policies map[string]ConflictPolicy, generators []Generator) error {
+	known := make(map[string]bool, len(generators))
+	for _, gen := range generators {
+		known[gen.Name()] = true
+	}
+
+	var unknown []string
+	for name := range policies {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+	return fmt.Errorf("unknown generators in conflict policies: %s", strings.Join(unknown, ", "))
+}
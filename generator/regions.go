@@ -0,0 +1,218 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Managed regions let generated files carry sections that proser owns.
+// On regeneration only the content between the markers is replaced;
+// everything outside them is preserved as written by the team.
+const (
+	regionBeginFormat = "<!-- proser:begin section=%s -->\n"
+	regionEnd         = "<!-- proser:end -->"
+
+	// managedRegionsNotice is placed at the top of files with managed regions
+	managedRegionsNotice = "<!-- Sections between proser:begin and proser:end markers are regenerated by proser. " +
+		"Add your own content outside of them to keep it across updates. -->\n"
+)
+
+var regionBeginPattern = regexp.MustCompile(`^<!-- proser:begin section=([A-Za-z0-9_.-]+) -->$`)
+
+// regionSegment is either unmanaged text (empty name) or a managed section
+type regionSegment struct {
+	name string
+	text string // full text, including markers for managed sections
+}
+
+// ManagedSection wraps content in proser section markers
+func ManagedSection(name, content string) string {
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return fmt.Sprintf(regionBeginFormat, name) + content + regionEnd + "\n"
+}
+
+// writeManagedSection renders a section with write and appends it wrapped in markers.
+// Sections that render nothing are omitted.
+func writeManagedSection(sb *strings.Builder, name string, write func(sb *strings.Builder)) {
+	var section strings.Builder
+	write(&section)
+	if section.Len() == 0 {
+		return
+	}
+	sb.WriteString(ManagedSection(name, section.String()))
+}
+
+// codeFence follows fenced code blocks line by line, so markers shown in a Markdown
+// example are not taken for real ones
+type codeFence struct {
+	marker string // the opening fence, e.g. "```", while inside a block
+}
+
+// skip reports whether line opens, closes or lies inside a fenced code block
+func (f *codeFence) skip(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if f.marker != "" {
+		if strings.HasPrefix(trimmed, f.marker) && strings.Trim(trimmed, f.marker[:1]) == "" {
+			f.marker = ""
+		}
+		return true
+	}
+	for _, ch := range []string{"`", "~"} {
+		if strings.HasPrefix(trimmed, ch+ch+ch) {
+			f.marker = strings.Repeat(ch, len(trimmed)-len(strings.TrimLeft(trimmed, ch)))
+			return true
+		}
+	}
+	return false
+}
+
+// HasManagedRegions reports whether content contains at least one managed section
+// outside of fenced code blocks
+func HasManagedRegions(content string) bool {
+	var fence codeFence
+	for _, line := range strings.Split(content, "\n") {
+		if fence.skip(line) {
+			continue
+		}
+		if regionBeginPattern.MatchString(strings.TrimSpace(line)) {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeManagedRegions replaces the managed sections of existing with those from generated.
+// Unmanaged text in existing is kept, sections no longer generated are dropped, and new
+// sections are inserted after the closest preceding section that already exists.
+func MergeManagedRegions(existing, generated string) (string, error) {
+	oldSegments, err := parseRegions(existing)
+	if err != nil {
+		return "", fmt.Errorf("existing content: %w", err)
+	}
+	newSegments, err := parseRegions(generated)
+	if err != nil {
+		return "", fmt.Errorf("generated content: %w", err)
+	}
+
+	// Index generated sections by name, preserving their order
+	var order []string
+	sections := make(map[string]string)
+	for _, seg := range newSegments {
+		if seg.name == "" {
+			continue
+		}
+		order = append(order, seg.name)
+		sections[seg.name] = seg.text
+	}
+
+	// Replace or drop existing managed sections
+	present := make(map[string]bool)
+	var merged []regionSegment
+	for _, seg := range oldSegments {
+		if seg.name == "" {
+			merged = append(merged, seg)
+			continue
+		}
+		text, ok := sections[seg.name]
+		if !ok || present[seg.name] {
+			continue
+		}
+		present[seg.name] = true
+		merged = append(merged, regionSegment{name: seg.name, text: text})
+	}
+
+	// Insert generated sections missing from the existing content
+	for i, name := range order {
+		if present[name] {
+			continue
+		}
+		merged = insertSection(merged, order[:i], regionSegment{name: name, text: sections[name]})
+		present[name] = true
+	}
+
+	var sb strings.Builder
+	for _, seg := range merged {
+		sb.WriteString(seg.text)
+	}
+	return sb.String(), nil
+}
+
+// insertSection places seg after the last of the preceding sections found in segments.
+// Without a preceding section it goes before the first managed section.
+func insertSection(segments []regionSegment, preceding []string, seg regionSegment) []regionSegment {
+	pos := -1
+	for i := len(preceding) - 1; i >= 0 && pos < 0; i-- {
+		for j, existing := range segments {
+			if existing.name == preceding[i] {
+				pos = j + 1
+				break
+			}
+		}
+	}
+	if pos < 0 {
+		pos = len(segments)
+		for j, existing := range segments {
+			if existing.name != "" {
+				pos = j
+				break
+			}
+		}
+	}
+
+	result := make([]regionSegment, 0, len(segments)+1)
+	result = append(result, segments[:pos]...)
+	result = append(result, seg)
+	return append(result, segments[pos:]...)
+}
+
+// parseRegions splits content into unmanaged text and managed sections. Markers inside
+// fenced code blocks are ordinary text.
+func parseRegions(content string) ([]regionSegment, error) {
+	var segments []regionSegment
+	var current strings.Builder
+	var fence codeFence
+	currentName := ""
+
+	flush := func(name string) {
+		if current.Len() > 0 {
+			segments = append(segments, regionSegment{name: name, text: current.String()})
+		}
+		current.Reset()
+	}
+
+	for lineNo, line := range strings.SplitAfter(content, "\n") {
+		if fence.skip(line) {
+			current.WriteString(line)
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if match := regionBeginPattern.FindStringSubmatch(trimmed); match != nil {
+			if currentName != "" {
+				return nil, fmt.Errorf("line %d: section %q starts before section %q ends", lineNo+1, match[1], currentName)
+			}
+			flush("")
+			currentName = match[1]
+			current.WriteString(line)
+			continue
+		}
+		if trimmed == regionEnd {
+			if currentName == "" {
+				return nil, fmt.Errorf("line %d: %s without a matching begin marker", lineNo+1, regionEnd)
+			}
+			current.WriteString(line)
+			flush(currentName)
+			currentName = ""
+			continue
+		}
+		current.WriteString(line)
+	}
+
+	if currentName != "" {
+		return nil, fmt.Errorf("section %q is missing its end marker", currentName)
+	}
+	flush("")
+	return segments, nil
+}
@@ -0,0 +1,139 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeManagedRegions(t *testing.T) {
+	a := ManagedSection("a", "new a")
+	b := ManagedSection("b", "new b")
+	c := ManagedSection("c", "new c")
+
+	tests := []struct {
+		name      string
+		existing  string
+		generated string
+		want      string
+	}{
+		{
+			name:      "replaces sections and keeps unmanaged text",
+			existing:  "# Title\n" + ManagedSection("a", "old a") + "\n## Team Notes\nkeep me\n" + ManagedSection("b", "old b"),
+			generated: "# Title\n" + a + b,
+			want:      "# Title\n" + a + "\n## Team Notes\nkeep me\n" + b,
+		},
+		{
+			name:      "drops sections no longer generated",
+			existing:  a + "notes\n" + ManagedSection("gone", "old") + b,
+			generated: a + b,
+			want:      a + "notes\n" + b,
+		},
+		{
+			name:      "inserts new section after its predecessor",
+			existing:  a + "notes\n" + c,
+			generated: a + b + c,
+			want:      a + b + "notes\n" + c,
+		},
+		{
+			name:      "inserts leading section before the first managed one",
+			existing:  "# Title\n" + b,
+			generated: a + b,
+			want:      "# Title\n" + a + b,
+		},
+		{
+			name:      "keeps the first of duplicated sections",
+			existing:  ManagedSection("a", "one") + "between\n" + ManagedSection("a", "two"),
+			generated: a,
+			want:      a + "between\n",
+		},
+		{
+			name:      "markers in fenced code are text",
+			existing:  "# Docs\n```markdown\n<!-- proser:begin section=a -->\nexample\n<!-- proser:end -->\n```\n" + ManagedSection("a", "old a"),
+			generated: a,
+			want:      "# Docs\n```markdown\n<!-- proser:begin section=a -->\nexample\n<!-- proser:end -->\n```\n" + a,
+		},
+		{
+			name:      "longer and tilde fences close only on a matching fence",
+			existing:  "~~~~\n```\n<!-- proser:end -->\n~~~\n~~~~\n" + ManagedSection("a", "old a"),
+			generated: a,
+			want:      "~~~~\n```\n<!-- proser:end -->\n~~~\n~~~~\n" + a,
+		},
+		{
+			name:      "fences inside a managed section",
+			existing:  ManagedSection("a", "```\nold\n```"),
+			generated: ManagedSection("a", "```\nnew\n```"),
+			want:      ManagedSection("a", "```\nnew\n```"),
+		},
+		{
+			name:      "accepts indented markers",
+			existing:  "  <!-- proser:begin section=a -->\nold\n  <!-- proser:end -->\n",
+			generated: a,
+			want:      a,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergeManagedRegions(tt.existing, tt.generated)
+			if err != nil {
+				t.Fatalf("MergeManagedRegions() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MergeManagedRegions() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeManagedRegionsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		wantErr  string
+	}{
+		{
+			name:     "nested begin",
+			existing: "<!-- proser:begin section=a -->\n<!-- proser:begin section=b -->\n",
+			wantErr:  `line 2: section "b" starts before section "a" ends`,
+		},
+		{
+			name:     "end without begin",
+			existing: "text\n<!-- proser:end -->\n",
+			wantErr:  "line 2: <!-- proser:end --> without a matching begin marker",
+		},
+		{
+			name:     "missing end",
+			existing: "<!-- proser:begin section=a -->\ntext\n",
+			wantErr:  `section "a" is missing its end marker`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := MergeManagedRegions(tt.existing, ManagedSection("a", "new"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("MergeManagedRegions() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHasManagedRegions(t *testing.T) {
+	tests := []struct {
+		content string
+		want    bool
+	}{
+		{ManagedSection("a", "text"), true},
+		{"# Title\n  <!-- proser:begin section=x.y -->\n", true},
+		{"# Title\n<!-- proser:end -->\n", false},
+		{"<!-- proser:begin section=bad name -->\n", false},
+		{"```\n<!-- proser:begin section=a -->\n```\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := HasManagedRegions(tt.content); got != tt.want {
+			t.Errorf("HasManagedRegions(%q) = %v, want %v", tt.content, got, tt.want)
+		}
+	}
+}
@@ -66,7 +66,7 @@ func generateFeatureTemplateSpec(ctx GenerateContext) string {
 		sb.WriteString("- [ ] Business Logic: `[file paths]`\n")
 		sb.WriteString("- [ ] API Endpoints: `[file paths]`\n")
 		if ctx.Config.Backend.Database != "" {
-			sb.WriteString("- [ ] Database Changes: `[migrations/schema]`\n")
+			sb.WriteString(fmt.Sprintf("- [ ] Database Changes (%s): `[migrations/schema]`\n", ctx.Config.Backend.Database))
 		}
 		sb.WriteString("\n")
 	}
@@ -0,0 +1,329 @@
+package generator
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/mongoose84/proser/filesystem"
+	"gopkg.in/yaml.v3"
+)
+
+// commonTask is a project command listed in the Common Tasks table of AGENTS.md
+type commonTask struct {
+	kind    string // Build, Test, Lint, Format or Run
+	command string
+	source  string // file the command was found in, or "Go conventions"
+}
+
+// taskKinds maps each kind of task to the target names that provide it, in order of preference.
+// "check" is left out on purpose: it usually runs the whole CI suite, not just the linters.
+var taskKinds = []struct {
+	kind  string
+	names []string
+}{
+	{"Build", []string{"build", "compile"}},
+	{"Test", []string{"test", "tests", "test:unit", "unit"}},
+	{"Lint", []string{"lint", "vet", "typecheck"}},
+	{"Format", []string{"fmt", "format"}},
+	{"Run", []string{"run", "dev", "start", "serve"}},
+}
+
+// taskSource lists the targets a task runner file defines and how to invoke one
+type taskSource struct {
+	files   []string
+	targets func(content string) []string
+	command func(fsys filesystem.FileSystem, root, target string) string
+}
+
+// taskSources are checked in order; each contributes at most one command per kind
+var taskSources = []taskSource{
+	{[]string{"Makefile", "makefile", "GNUmakefile"}, makeTargets, prefixed("make")},
+	{[]string{"justfile", "Justfile", ".justfile"}, justRecipes, prefixed("just")},
+	{[]string{"Taskfile.yml", "Taskfile.yaml", "taskfile.yml", "taskfile.yaml"}, taskfileTasks, prefixed("task")},
+	{[]string{"package.json"}, packageScripts, packageScriptCommand},
+}
+
+var (
+	// A rule names one or more targets before ":" or "::"; ":=", "::=" and ":::=" are assignments
+	makeTargetPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9_./-]*(?:[ \t]+[A-Za-z0-9][A-Za-z0-9_./-]*)*)[ \t]*::?([^:=]|$)`)
+	justRecipePattern = regexp.MustCompile(`^@?([A-Za-z0-9_-]+)(\s+[^:]*)?:([^=]|$)`)
+)
+
+// discoverTasks finds the build, test, lint, format and run commands of the project in
+// its task runner files. Go conventions fill in the kinds no task runner provides.
+func discoverTasks(fsys filesystem.FileSystem, root string) []commonTask {
+	var tasks []commonTask
+	for _, source := range taskSources {
+		for _, name := range source.files {
+			data, err := fsys.ReadFile(filepath.Join(root, name))
+			if err != nil {
+				continue
+			}
+			targets := source.targets(string(data))
+			for _, kind := range taskKinds {
+				if target := firstTarget(targets, kind.names); target != "" {
+					tasks = append(tasks, commonTask{kind.kind, source.command(fsys, root, target), name})
+				}
+			}
+			break
+		}
+	}
+
+	tasks = append(tasks, pyprojectTasks(fsys, root)...)
+	tasks = append(tasks, goTasks(fsys, root, tasks)...)
+
+	// Group by kind, keeping source order within a kind
+	order := make(map[string]int, len(taskKinds))
+	for i, kind := range taskKinds {
+		order[kind.kind] = i
+	}
+	sort.SliceStable(tasks, func(i, j int) bool { return order[tasks[i].kind] < order[tasks[j].kind] })
+	return tasks
+}
+
+// writeTaskTable writes tasks as a Markdown table
+func writeTaskTable(sb *strings.Builder, tasks []commonTask) {
+	sb.WriteString("| Task | Command | Defined in |\n")
+	sb.WriteString("|------|---------|------------|\n")
+	for _, task := range tasks {
+		sb.WriteString(fmt.Sprintf("| %s | `%s` | %s |\n", task.kind, task.command, task.source))
+	}
+	sb.WriteString("\n")
+}
+
+// firstTarget returns the first of names that is among targets
+func firstTarget(targets, names []string) string {
+	defined := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		defined[target] = true
+	}
+	for _, name := range names {
+		if defined[name] {
+			return name
+		}
+	}
+	return ""
+}
+
+// prefixed returns a command builder that runs targets with the given tool
+func prefixed(tool string) func(filesystem.FileSystem, string, string) string {
+	return func(_ filesystem.FileSystem, _, target string) string {
+		return tool + " " + target
+	}
+}
+
+// makeTargets returns the explicit targets of a Makefile, without special targets like .PHONY
+func makeTargets(content string) []string {
+	var targets []string
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		if m := makeTargetPattern.FindStringSubmatch(scanner.Text()); m != nil {
+			targets = append(targets, strings.Fields(m[1])...)
+		}
+	}
+	return targets
+}
+
+// justRecipes returns the recipe names of a justfile
+func justRecipes(content string) []string {
+	var recipes []string
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "set ") || strings.HasPrefix(line, "alias ") {
+			continue
+		}
+		if m := justRecipePattern.FindStringSubmatch(line); m != nil {
+			recipes = append(recipes, m[1])
+		}
+	}
+	return recipes
+}
+
+// taskfileTasks returns the task names of a Taskfile
+func taskfileTasks(content string) []string {
+	var taskfile struct {
+		Tasks map[string]interface{} `yaml:"tasks"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &taskfile); err != nil {
+		return nil
+	}
+	return mapKeys(taskfile.Tasks)
+}
+
+// packageScripts returns the script names of a package.json
+func packageScripts(content string) []string {
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal([]byte(content), &pkg); err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(pkg.Scripts))
+	for name := range pkg.Scripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// packageScriptCommand runs a script with the package manager whose lockfile is present
+func packageScriptCommand(fsys filesystem.FileSystem, root, script string) string {
+	lockfiles := []struct{ file, manager string }{
+		{"pnpm-lock.yaml", "pnpm"},
+		{"yarn.lock", "yarn"},
+		{"bun.lockb", "bun"},
+		{"bun.lock", "bun"},
+	}
+	manager := "npm"
+	for _, lock := range lockfiles {
+		if _, err := fsys.Stat(filepath.Join(root, lock.file)); err == nil {
+			manager = lock.manager
+			break
+		}
+	}
+	if manager == "npm" && (script == "test" || script == "start") {
+		return "npm " + script
+	}
+	return manager + " run " + script
+}
+
+// pyprojectTasks reads the task runner tables and console scripts of pyproject.toml
+func pyprojectTasks(fsys filesystem.FileSystem, root string) []commonTask {
+	data, err := fsys.ReadFile(filepath.Join(root, "pyproject.toml"))
+	if err != nil {
+		return nil
+	}
+	config := make(map[string]interface{})
+	if _, err := toml.Decode(string(data), &config); err != nil {
+		return nil
+	}
+
+	runners := []struct {
+		keys   []string
+		prefix string
+	}{
+		{[]string{"tool", "poe", "tasks"}, "poe"},
+		{[]string{"tool", "pdm", "scripts"}, "pdm run"},
+		{[]string{"tool", "hatch", "envs", "default", "scripts"}, "hatch run"},
+	}
+	var tasks []commonTask
+	for _, runner := range runners {
+		table, ok := tableAt(config, runner.keys...)
+		if !ok {
+			continue
+		}
+		targets := mapKeys(table)
+		for _, kind := range taskKinds {
+			if target := firstTarget(targets, kind.names); target != "" {
+				tasks = append(tasks, commonTask{kind.kind, runner.prefix + " " + target, "pyproject.toml"})
+			}
+		}
+	}
+
+	// Console scripts are the way to run the project when no runner defines a run task
+	if hasKind(tasks, "Run") {
+		return tasks
+	}
+	scripts, ok := tableAt(config, "project", "scripts")
+	prefix := ""
+	if !ok {
+		scripts, ok = tableAt(config, "tool", "poetry", "scripts")
+		prefix = "poetry run "
+	}
+	if names := mapKeys(scripts); ok && len(names) > 0 {
+		tasks = append(tasks, commonTask{"Run", prefix + names[0], "pyproject.toml"})
+	}
+	return tasks
+}
+
+// goTasks returns the go tool commands for the kinds not found elsewhere in a Go module
+func goTasks(fsys filesystem.FileSystem, root string, found []commonTask) []commonTask {
+	if _, err := fsys.Stat(filepath.Join(root, "go.mod")); err != nil {
+		return nil
+	}
+
+	conventions := []commonTask{
+		{"Build", "go build ./...", "Go conventions"},
+		{"Test", "go test ./...", "Go conventions"},
+		{"Lint", "go vet ./...", "Go conventions"},
+		{"Format", "gofmt -w .", "Go conventions"},
+	}
+	if run := goRunCommand(fsys, root); run != "" {
+		conventions = append(conventions, commonTask{"Run", run, "Go conventions"})
+	}
+
+	var tasks []commonTask
+	for _, task := range conventions {
+		if !hasKind(found, task.kind) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// goRunCommand returns go run for the root main package or the single command under cmd/
+func goRunCommand(fsys filesystem.FileSystem, root string) string {
+	if _, err := fsys.Stat(filepath.Join(root, "main.go")); err == nil {
+		return "go run ."
+	}
+
+	var commands []string
+	cmdDir := filepath.Join(root, "cmd")
+	_ = filesystem.NewIgnoreWalker(fsys).WalkFrom(root, cmdDir, func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return filepath.SkipDir
+		}
+		if info.IsDir() && p != cmdDir && filepath.Dir(p) != cmdDir {
+			return filepath.SkipDir
+		}
+		if !info.IsDir() && info.Name() == "main.go" && filepath.Dir(filepath.Dir(p)) == cmdDir {
+			commands = append(commands, filepath.Base(filepath.Dir(p)))
+		}
+		return nil
+	})
+	if len(commands) == 1 {
+		return "go run ./cmd/" + commands[0]
+	}
+	return ""
+}
+
+// hasKind reports whether tasks contains a command of the given kind
+func hasKind(tasks []commonTask, kind string) bool {
+	for _, task := range tasks {
+		if task.kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// tableAt follows keys through nested TOML tables
+func tableAt(config map[string]interface{}, keys ...string) (map[string]interface{}, bool) {
+	table := config
+	for _, key := range keys {
+		next, ok := table[key].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		table = next
+	}
+	return table, true
+}
+
+// mapKeys returns the keys of m in sorted order
+func mapKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
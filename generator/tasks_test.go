@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mongoose84/proser/filesystem"
+)
+
+func TestDiscoverTasks(t *testing.T) {
+	const target = "/project"
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []commonTask
+	}{
+		{
+			name: "no task runner",
+		},
+		{
+			name: "makefile targets by preference",
+			files: map[string]string{
+				"Makefile": ".PHONY: build test\nbuild:\n\tgo build\ncompile: build\ntest:\n\tgo test\nVAR := 1\nlint:\n\tgolangci-lint run\n",
+			},
+			want: []commonTask{
+				{"Build", "make build", "Makefile"},
+				{"Test", "make test", "Makefile"},
+				{"Lint", "make lint", "Makefile"},
+			},
+		},
+		{
+			name: "makefile assignments and rules with several targets",
+			files: map[string]string{
+				"Makefile": "build ::= ignored\nrun :::= ignored\nfmt::\n\tgofmt -w .\nbuild test: deps\n\tgo $@ ./...\n",
+			},
+			want: []commonTask{
+				{"Build", "make build", "Makefile"},
+				{"Test", "make test", "Makefile"},
+				{"Format", "make fmt", "Makefile"},
+			},
+		},
+		{
+			name: "check is not a lint task",
+			files: map[string]string{
+				"Makefile": "check: lint test\ntypecheck:\n\tmypy .\n",
+			},
+			want: []commonTask{
+				{"Lint", "make typecheck", "Makefile"},
+			},
+		},
+		{
+			name: "package scripts use the lockfile's manager",
+			files: map[string]string{
+				"package.json":   `{"scripts": {"dev": "vite", "test": "vitest", "check": "npm run lint && npm test", "format": "prettier -w ."}}`,
+				"pnpm-lock.yaml": "",
+			},
+			want: []commonTask{
+				{"Test", "pnpm run test", "package.json"},
+				{"Format", "pnpm run format", "package.json"},
+				{"Run", "pnpm run dev", "package.json"},
+			},
+		},
+		{
+			name: "npm shortcuts for test and start",
+			files: map[string]string{
+				"package.json": `{"scripts": {"start": "node .", "test": "jest", "build": "tsc"}}`,
+			},
+			want: []commonTask{
+				{"Build", "npm run build", "package.json"},
+				{"Test", "npm test", "package.json"},
+				{"Run", "npm start", "package.json"},
+			},
+		},
+		{
+			name: "justfile and taskfile",
+			files: map[string]string{
+				"justfile":     "set shell := [\"bash\", \"-c\"]\nalias b := build\nrun := \"x\"\nbuild:\n  cargo build\n@fmt target='.':\n  cargo fmt\n",
+				"Taskfile.yml": "version: '3'\ntasks:\n  test:\n    cmds: [cargo test]\n",
+			},
+			want: []commonTask{
+				{"Build", "just build", "justfile"},
+				{"Test", "task test", "Taskfile.yml"},
+				{"Format", "just fmt", "justfile"},
+			},
+		},
+		{
+			name: "go conventions fill the gaps",
+			files: map[string]string{
+				"go.mod":              "module x\n",
+				"Makefile":            "test:\n\tgo test -race ./...\n",
+				"cmd/server/main.go":  "package main\n",
+				"cmd/server/flags.go": "package main\n",
+			},
+			want: []commonTask{
+				{"Build", "go build ./...", "Go conventions"},
+				{"Test", "make test", "Makefile"},
+				{"Lint", "go vet ./...", "Go conventions"},
+				{"Format", "gofmt -w .", "Go conventions"},
+				{"Run", "go run ./cmd/server", "Go conventions"},
+			},
+		},
+		{
+			name: "pyproject runner and console script",
+			files: map[string]string{
+				"pyproject.toml": "[project.scripts]\nmycli = \"pkg:main\"\n\n[tool.poe.tasks]\ntest = \"pytest\"\nlint = \"ruff check .\"\n",
+			},
+			want: []commonTask{
+				{"Test", "poe test", "pyproject.toml"},
+				{"Lint", "poe lint", "pyproject.toml"},
+				{"Run", "mycli", "pyproject.toml"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := filesystem.NewMemoryFileSystem()
+			if err := fsys.MkdirAll(target, 0755); err != nil {
+				t.Fatal(err)
+			}
+			for relPath, content := range tt.files {
+				writeFile(t, fsys, target, relPath, content)
+			}
+			if got := discoverTasks(fsys, target); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("discoverTasks() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
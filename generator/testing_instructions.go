@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/mongoose84/proser/config"
+	"github.com/mongoose84/proser/language"
 )
 
 // TestingInstructionsGenerator generates testing-specific instructions
@@ -18,12 +19,16 @@ func (g *TestingInstructionsGenerator) Name() string {
 // Generate creates testing instructions content
 func (g *TestingInstructionsGenerator) Generate(ctx GenerateContext) (map[string]string, error) {
 	cfg := ctx.Config
+	languages, err := ctx.registry()
+	if err != nil {
+		return nil, err
+	}
 
 	var sb strings.Builder
 
 	// --- Frontmatter ---
 	sb.WriteString("---\n")
-	sb.WriteString(fmt.Sprintf("applyTo: \"%s\"\n", testingApplyTo(cfg)))
+	sb.WriteString(fmt.Sprintf("applyTo: \"%s\"\n", testingApplyTo(cfg, languages)))
 	sb.WriteString("description: \"Testing guidelines with context engineering\"\n")
 	sb.WriteString("---\n")
 	sb.WriteString("# Testing Guidelines\n\n")
@@ -31,14 +36,14 @@ func (g *TestingInstructionsGenerator) Generate(ctx GenerateContext) (map[string
 	sb.WriteString("Inherits from [global instructions](../copilot-instructions.md).\n\n")
 
 	// --- Context Loading ---
-	sb.WriteString("## Context Loading\n")
-	sb.WriteString("Review [project conventions](../../README.md) and\n")
+	tests := "existing tests"
 	if cfg.Testing.Framework != "" {
-		sb.WriteString(fmt.Sprintf("[existing %s tests](../../) before writing tests.\n\n",
-			cfg.Testing.Framework))
-	} else {
-		sb.WriteString("[existing tests](../../) before writing tests.\n\n")
+		tests = fmt.Sprintf("existing %s tests", cfg.Testing.Framework)
 	}
+	writeContextLoading(&sb, ctx, []contextGroup{
+		{"project conventions", []string{"README.md"}, 1},
+		{tests, []string{"tests/", "test/", "__tests__/", "src/test/", "spec/", "e2e/", "cypress/"}, 3},
+	}, "before writing tests")
 
 	// --- Deterministic Requirements ---
 	sb.WriteString("## Deterministic Requirements\n")
@@ -49,24 +54,10 @@ func (g *TestingInstructionsGenerator) Generate(ctx GenerateContext) (map[string
 	sb.WriteString("- Cover both happy paths and error conditions\n")
 
 	// Framework-specific requirements (folded in as bullets)
-	framework := strings.ToLower(cfg.Testing.Framework)
-	switch framework {
-	case "jest":
-		sb.WriteString("- Use `describe`/`it` blocks for organization\n")
-		sb.WriteString("- Use `beforeEach`/`afterEach` for setup and teardown\n")
-	case "pytest":
-		sb.WriteString("- Use pytest fixtures for setup and teardown\n")
-		sb.WriteString("- Use `@pytest.mark.parametrize` for data-driven tests\n")
-	case "junit":
-		sb.WriteString("- Use JUnit 5 annotations (`@BeforeEach`, `@ParameterizedTest`)\n")
-		sb.WriteString("- Use Mockito for mocking dependencies\n")
-	case "go testing":
-		sb.WriteString("- Use table-driven tests for multiple scenarios\n")
-		sb.WriteString("- Use `testing.T` for unit tests, `testing.B` for benchmarks\n")
-	default:
-		if cfg.Testing.Framework != "" {
-			sb.WriteString(fmt.Sprintf("- Follow %s conventions and patterns\n", cfg.Testing.Framework))
-		}
+	if fw, ok := languages.LookupFramework(cfg.Testing.Framework); ok {
+		writeBullets(&sb, fw.Guidelines)
+	} else if cfg.Testing.Framework != "" {
+		sb.WriteString(fmt.Sprintf("- Follow %s conventions and patterns\n", cfg.Testing.Framework))
 	}
 
 	if cfg.Testing.Strategy != "" {
@@ -82,19 +73,8 @@ func (g *TestingInstructionsGenerator) Generate(ctx GenerateContext) (map[string
 	sb.WriteString("Generate tests with:\n")
 
 	// Language-aware checklist
-	if cfg.HasBackend() {
-		lang := strings.ToLower(cfg.Backend.Language)
-		switch lang {
-		case "go":
-			sb.WriteString("- [ ] Table-driven test patterns\n")
-			sb.WriteString("- [ ] Benchmark tests for performance-critical code\n")
-		case "python":
-			sb.WriteString("- [ ] Pytest fixtures and parametrized cases\n")
-		case "java":
-			sb.WriteString("- [ ] JUnit test classes with proper annotations\n")
-		case "javascript", "typescript", "node", "js", "ts":
-			sb.WriteString("- [ ] Async/await patterns for async code\n")
-		}
+	if lang, ok := testedLanguage(cfg, languages); ok {
+		writeChecklist(&sb, lang.TestingPatterns)
 	}
 
 	sb.WriteString("- [ ] Setup and teardown for shared state\n")
@@ -107,18 +87,37 @@ func (g *TestingInstructionsGenerator) Generate(ctx GenerateContext) (map[string
 	}, nil
 }
 
-// testingApplyTo returns a sensible glob for test files
-func testingApplyTo(cfg config.ProjectConfig) string {
+// testedLanguage returns the backend language, or the frontend language of a project without one
+func testedLanguage(cfg config.ProjectConfig, languages *language.Registry) (*language.LanguageInfo, bool) {
+	if cfg.HasBackend() {
+		return languages.LookupLanguage(cfg.Backend.Language)
+	}
+	if cfg.HasFrontend() {
+		return languages.LookupLanguage(cfg.Frontend.Language)
+	}
+	return nil, false
+}
+
+// testingApplyTo returns the globs for the test files of the project's languages and
+// testing framework, or a generic test directory glob when none are known
+func testingApplyTo(cfg config.ProjectConfig, languages *language.Registry) string {
+	var globs []string
 	if cfg.HasBackend() {
-		lang := strings.ToLower(cfg.Backend.Language)
-		switch lang {
-		case "go":
-			return "**/*_test.go"
-		case "python":
-			return "**/test_*.py"
-		case "java":
-			return "**/test/**/*.java"
+		if lang, ok := languages.LookupLanguage(cfg.Backend.Language); ok {
+			globs = append(globs, lang.TestFilePatterns...)
 		}
 	}
-	return "**/test/**"
+	if fw, ok := languages.LookupFramework(cfg.Testing.Framework); ok {
+		globs = append(globs, fw.TestFilePatterns...)
+	}
+	if cfg.HasFrontend() {
+		if lang, ok := languages.LookupLanguage(cfg.Frontend.Language); ok {
+			globs = append(globs, lang.TestFilePatterns...)
+		}
+	}
+
+	if len(globs) == 0 {
+		return "**/test/**"
+	}
+	return joinGlobs(globs)
 }
@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/mongoose84/proser/config"
+	"github.com/mongoose84/proser/language"
+)
+
+func TestTestingApplyTo(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.ProjectConfig
+		want string
+	}{
+		{
+			name: "unknown languages fall back to a test directory",
+			cfg:  config.ProjectConfig{Backend: &config.BackendConfig{Language: "COBOL"}},
+			want: "**/test/**",
+		},
+		{
+			name: "go backend",
+			cfg:  config.ProjectConfig{Backend: &config.BackendConfig{Language: "Go"}, Testing: config.TestingConfig{Framework: "Go testing"}},
+			want: "**/*_test.go",
+		},
+		{
+			name: "typescript backend without a javascript test runner",
+			cfg:  config.ProjectConfig{Backend: &config.BackendConfig{Language: "TypeScript"}, Testing: config.TestingConfig{Framework: "node:test"}},
+			want: "**/*.test.*,**/*.spec.*,**/__tests__/**",
+		},
+		{
+			name: "fullstack combines backend, framework and frontend globs",
+			cfg: config.ProjectConfig{
+				Backend:  &config.BackendConfig{Language: "Go"},
+				Frontend: &config.FrontendConfig{Language: "JavaScript"},
+				Testing:  config.TestingConfig{Framework: "Vitest"},
+			},
+			want: "**/*_test.go,**/*.{test,spec}.{js,jsx,ts,tsx},**/*.test.*,**/*.spec.*,**/__tests__/**",
+		},
+	}
+
+	languages := language.NewDefaultRegistry()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testingApplyTo(tt.cfg, languages); got != tt.want {
+				t.Errorf("testingApplyTo() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mongoose84/proser/config"
+	"github.com/mongoose84/proser/filesystem"
+)
+
+// maxTreeEntries limits how many entries of a single directory are listed
+const maxTreeEntries = 20
+
+// treeSkipDirs are dependency and build output directories left out of the tree
+var treeSkipDirs = map[string]bool{
+	"node_modules": true, "vendor": true, "dist": true, "build": true, "target": true,
+	"bin": true, "obj": true, "out": true, "venv": true, "__pycache__": true, "coverage": true,
+}
+
+// wellKnownDirs annotates common top-level directory names with their purpose
+var wellKnownDirs = map[string]string{
+	"api":        "API definitions",
+	"app":        "Application code",
+	"backend":    "Server-side code",
+	"cmd":        "Application entry points",
+	"components": "UI components",
+	"config":     "Configuration",
+	"configs":    "Configuration",
+	"deploy":     "Deployment configuration",
+	"docs":       "Documentation",
+	"e2e":        "End-to-end tests",
+	"examples":   "Usage examples",
+	"frontend":   "Client-side code",
+	"internal":   "Private application code",
+	"lib":        "Library code",
+	"migrations": "Database migrations",
+	"pages":      "Page components",
+	"pkg":        "Public library code",
+	"public":     "Static assets",
+	"scripts":    "Build and maintenance scripts",
+	"shared":     "Shared types and utilities",
+	"src":        "Source code",
+	"test":       "Test files",
+	"tests":      "Test files",
+	"utils":      "Utilities",
+	"web":        "Web client",
+}
+
+// treeEntry is a file or directory shown in the repository tree
+type treeEntry struct {
+	name     string
+	dir      bool
+	comment  string
+	children []*treeEntry
+	more     int // entries left out because of maxTreeEntries
+}
+
+// treeLine is a rendered tree line with an optional comment
+type treeLine struct {
+	text    string
+	comment string
+}
+
+// scanRepositoryTree reads the layout of root, a directory inside the project at top,
+// up to depth levels.
+// Hidden entries, dependency and build output directories, ignored files and
+// AGENTS.md files are left out.
+func scanRepositoryTree(fsys filesystem.FileSystem, top, root string, depth int) (*treeEntry, error) {
+	entries := map[string]*treeEntry{".": {dir: true}}
+
+	err := filesystem.NewIgnoreWalker(fsys).WalkFrom(top, root, func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil || rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		name := info.Name()
+
+		skip := strings.HasPrefix(name, ".") || name == "AGENTS.md"
+		if info.IsDir() {
+			skip = skip || treeSkipDirs[name]
+		}
+		if skip || strings.Count(rel, "/") >= depth {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		entry := &treeEntry{name: name, dir: info.IsDir()}
+		if entry.dir {
+			// Names like api or config mean something else further down the tree
+			if !strings.Contains(rel, "/") {
+				entry.comment = wellKnownDirs[strings.ToLower(name)]
+			}
+			entries[rel] = entry
+		}
+		if parent := entries[path.Dir(rel)]; parent != nil {
+			parent.children = append(parent.children, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+
+	tree := entries["."]
+	sortTree(tree)
+	return tree, nil
+}
+
+// sortTree orders directories before files, each alphabetically, and trims long listings
+func sortTree(entry *treeEntry) {
+	sort.SliceStable(entry.children, func(i, j int) bool {
+		a, b := entry.children[i], entry.children[j]
+		if a.dir != b.dir {
+			return a.dir
+		}
+		return a.name < b.name
+	})
+	if len(entry.children) > maxTreeEntries {
+		entry.more = len(entry.children) - maxTreeEntries
+		entry.children = entry.children[:maxTreeEntries]
+	}
+	for _, child := range entry.children {
+		sortTree(child)
+	}
+}
+
+// addTreeEntry inserts entry among the children of parent, keeping the sort order
+func addTreeEntry(parent *treeEntry, entry *treeEntry) {
+	i := sort.Search(len(parent.children), func(i int) bool {
+		child := parent.children[i]
+		if child.dir != entry.dir {
+			return !child.dir
+		}
+		return child.name >= entry.name
+	})
+	parent.children = append(parent.children, nil)
+	copy(parent.children[i+1:], parent.children[i:])
+	parent.children[i] = entry
+}
+
+// renderTree writes the tree as a code block, with comments aligned in one column
+func renderTree(sb *strings.Builder, rootName string, tree *treeEntry) {
+	lines := []treeLine{{text: rootName + "/"}}
+	lines = appendTreeLines(lines, tree, "")
+
+	width := 0
+	for _, line := range lines {
+		if n := utf8.RuneCountInString(line.text); line.comment != "" && n > width {
+			width = n
+		}
+	}
+
+	sb.WriteString("```\n")
+	for _, line := range lines {
+		sb.WriteString(line.text)
+		if line.comment != "" {
+			sb.WriteString(strings.Repeat(" ", width-utf8.RuneCountInString(line.text)+2))
+			sb.WriteString("# " + line.comment)
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("```\n\n")
+}
+
+// appendTreeLines appends the lines for the children of entry using box-drawing connectors
+func appendTreeLines(lines []treeLine, entry *treeEntry, indent string) []treeLine {
+	for i, child := range entry.children {
+		last := i == len(entry.children)-1 && entry.more == 0
+		connector, childIndent := "├── ", "│   "
+		if last {
+			connector, childIndent = "└── ", "    "
+		}
+
+		name := child.name
+		if child.dir {
+			name += "/"
+		}
+		lines = append(lines, treeLine{text: indent + connector + name, comment: child.comment})
+		lines = appendTreeLines(lines, child, indent+childIndent)
+	}
+	if entry.more > 0 {
+		lines = append(lines, treeLine{text: fmt.Sprintf("%s└── … %d more", indent, entry.more)})
+	}
+	return lines
+}
+
+// treeDepth returns the configured tree depth, or the default when it is not set
+func treeDepth(cfg config.ProjectConfig) int {
+	if cfg.Structure.TreeDepth < 1 {
+		return config.DefaultTreeDepth
+	}
+	return cfg.Structure.TreeDepth
+}
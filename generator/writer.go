@@ -1,15 +1,91 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/mongoose84/proser/filesystem"
+	"github.com/mongoose84/proser/input"
 )
 
+// FileStatus describes how a generated file compares to the file on disk
+type FileStatus string
+
+const (
+	// StatusNew means the file does not exist yet
+	StatusNew FileStatus = "new"
+	// StatusChanged means the file exists with different content
+	StatusChanged FileStatus = "changed"
+	// StatusUnchanged means the file exists with identical content
+	StatusUnchanged FileStatus = "unchanged"
+)
+
+// PlannedFile is a generated file paired with what is currently on disk
+type PlannedFile struct {
+	Path    string // relative to the target project root
+	Status  FileStatus
+	Current string // existing content, empty for new files
+	Content string // content to write, with managed regions merged into existing files
+}
+
+// Diff returns a unified diff from the current to the generated content
+func (p PlannedFile) Diff() string {
+	return UnifiedDiff(p.Path, p.Current, p.Content, p.Status != StatusNew)
+}
+
+// WriteAction describes what the writer did with a generated file
+type WriteAction string
+
+const (
+	// ActionCreated means the file did not exist and was written
+	ActionCreated WriteAction = "created"
+	// ActionUpdated means an existing file was replaced
+	ActionUpdated WriteAction = "updated"
+	// ActionUnchanged means the file already had the generated content
+	ActionUnchanged WriteAction = "unchanged"
+	// ActionSkipped means an existing file was kept because of the conflict policy
+	ActionSkipped WriteAction = "skipped"
+	// ActionBackedUp means an existing file was backed up and then replaced
+	ActionBackedUp WriteAction = "backed up"
+)
+
+// WriteResult records the outcome for a single generated file
+type WriteResult struct {
+	Path       string // relative to the target project root
+	Action     WriteAction
+	BackupPath string // set when Action is ActionBackedUp
+
+	content string // content on disk after writing
+}
+
 // Writer writes generated files to the filesystem
 type Writer struct {
 	FS filesystem.FileSystem
+
+	// Policy applies when a generated file differs from an existing one.
+	// The zero value behaves like PolicyOverwrite.
+	Policy ConflictPolicy
+
+	// GeneratorPolicies overrides Policy for specific generators, keyed by Generator.Name()
+	GeneratorPolicies map[string]ConflictPolicy
+
+	// BackupDir, when set, collects backups under <BackupDir>/<timestamp>/ relative to
+	// the target instead of writing *.bak files next to the originals
+	BackupDir string
+
+	// Prompter is asked per file when the policy is PolicyPrompt
+	Prompter input.InputCollector
+
+	// Manifest, when set, records every file written by RunGenerator. Files whose content
+	// still matches the manifest are overwritten without consulting the conflict policy.
+	Manifest *Manifest
+
+	backupStamp string // timestamp shared by all backups of one run
 }
 
 // NewWriter creates a new Writer
@@ -17,35 +93,255 @@ func NewWriter(fs filesystem.FileSystem) *Writer {
 	return &Writer{FS: fs}
 }
 
-// WriteFiles writes the generated files from a generator to the filesystem
-func (w *Writer) WriteFiles(targetPath string, files map[string]string) error {
-	for relPath, content := range files {
-		fullPath := filepath.Join(targetPath, relPath)
+// WriteFiles writes generated files to the filesystem using the writer's default policy
+func (w *Writer) WriteFiles(targetPath string, files map[string]string) ([]WriteResult, error) {
+	return w.writeFiles(targetPath, files, w.Policy)
+}
+
+// writeFiles writes generated files, resolving conflicts with existing files using policy
+func (w *Writer) writeFiles(targetPath string, files map[string]string, policy ConflictPolicy) ([]WriteResult, error) {
+	planned, err := w.Plan(targetPath, files)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]WriteResult, 0, len(planned))
+	for _, pf := range planned {
+		result := WriteResult{Path: pf.Path, content: pf.Content}
+
+		switch {
+		case pf.Status == StatusUnchanged:
+			result.Action = ActionUnchanged
+			results = append(results, result)
+			continue
+		case pf.Status == StatusNew:
+			result.Action = ActionCreated
+		case w.Manifest != nil && w.Manifest.Unmodified(pf.Path, pf.Current):
+			// Nobody edited the file since proser wrote it, so there is no conflict
+			result.Action = ActionUpdated
+		default:
+			action, err := w.resolveConflict(pf, policy)
+			if err != nil {
+				return nil, err
+			}
+			result.Action = action
+		}
+
+		if result.Action == ActionSkipped {
+			result.content = pf.Current
+			results = append(results, result)
+			continue
+		}
 
-		// Create parent directory
-		dir := filepath.Dir(fullPath)
-		if err := w.FS.MkdirAll(dir, 0755); err != nil {
-			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		if result.Action == ActionBackedUp {
+			backupPath, err := w.backup(targetPath, pf)
+			if err != nil {
+				return nil, err
+			}
+			result.BackupPath = backupPath
 		}
 
-		// Write file
-		if err := w.FS.WriteFile(fullPath, []byte(content), 0644); err != nil {
-			return fmt.Errorf("failed to write file %s: %w", fullPath, err)
+		if err := w.write(targetPath, filepath.Join(targetPath, pf.Path), pf.Content); err != nil {
+			return nil, err
 		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// resolveConflict decides what to do with a changed file under the given policy
+func (w *Writer) resolveConflict(pf PlannedFile, policy ConflictPolicy) (WriteAction, error) {
+	switch policy {
+	case PolicySkip:
+		return ActionSkipped, nil
+	case PolicyBackup:
+		return ActionBackedUp, nil
+	case PolicyPrompt:
+		return w.promptConflict(pf)
+	case PolicyOverwrite, "":
+		return ActionUpdated, nil
+	default:
+		return "", fmt.Errorf("unknown conflict policy %q", policy)
+	}
+}
+
+// promptConflict asks whether to overwrite, back up or keep a changed file
+func (w *Writer) promptConflict(pf PlannedFile) (WriteAction, error) {
+	if w.Prompter == nil {
+		return "", fmt.Errorf("conflict policy %q requires an input collector", PolicyPrompt)
+	}
+
+	question := input.Question{
+		Key:          pf.Path,
+		Prompt:       fmt.Sprintf("%s differs from the generated version. Overwrite? (yes/no/backup)", pf.Path),
+		DefaultValue: "no",
+	}
+	answers, err := w.Prompter.Collect([]input.Question{question})
+	if err != nil {
+		return "", fmt.Errorf("failed to ask about %s: %w", pf.Path, err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answers[question.Key])) {
+	case "yes", "y":
+		return ActionUpdated, nil
+	case "backup", "b":
+		return ActionBackedUp, nil
+	default:
+		return ActionSkipped, nil
+	}
+}
+
+// backup copies the current content of a file aside and returns the backup path. An
+// existing *.bak file is never replaced: later backups get the run's timestamp in their name.
+func (w *Writer) backup(targetPath string, pf PlannedFile) (string, error) {
+	backupPath := filepath.Join(targetPath, pf.Path) + ".bak"
+	if w.BackupDir != "" {
+		backupRoot := w.BackupDir
+		if !filepath.IsAbs(backupRoot) {
+			backupRoot = filepath.Join(targetPath, backupRoot)
+		}
+		backupPath = filepath.Join(backupRoot, w.stamp(), pf.Path)
+	} else if w.exists(backupPath) {
+		backupPath = filepath.Join(targetPath, pf.Path) + "." + w.stamp() + ".bak"
+	}
+	if w.exists(backupPath) {
+		return "", fmt.Errorf("failed to back up %s: %s already exists", pf.Path, backupPath)
+	}
+
+	if err := w.write(targetPath, backupPath, pf.Current); err != nil {
+		return "", fmt.Errorf("failed to back up %s: %w", pf.Path, err)
+	}
+	return backupPath, nil
+}
+
+// stamp returns the timestamp shared by all backups of one run
+func (w *Writer) stamp() string {
+	if w.backupStamp == "" {
+		w.backupStamp = time.Now().Format("20060102-150405")
+	}
+	return w.backupStamp
+}
+
+// exists reports whether something is stored at fullPath
+func (w *Writer) exists(fullPath string) bool {
+	_, err := w.FS.Stat(fullPath)
+	return err == nil
+}
+
+// write creates parent directories and writes a single file. Directories it creates below
+// targetPath are recorded in the manifest, so clean removes those and no others.
+func (w *Writer) write(targetPath, fullPath, content string) error {
+	// Create parent directory
+	dir := filepath.Dir(fullPath)
+	if w.Manifest != nil {
+		root := filepath.Clean(targetPath)
+		for d := dir; strings.HasPrefix(d, root+string(filepath.Separator)) && !w.exists(d); d = filepath.Dir(d) {
+			rel, err := filepath.Rel(root, d)
+			if err == nil {
+				w.Manifest.RecordDir(rel)
+			}
+		}
+	}
+	if err := w.FS.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	// Write file
+	if err := w.FS.WriteFile(fullPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", fullPath, err)
 	}
 	return nil
 }
 
-// RunGenerator executes a generator and writes its output
-func (w *Writer) RunGenerator(gen Generator, ctx GenerateContext) error {
+// policyFor returns the conflict policy for a generator
+func (w *Writer) policyFor(gen Generator) ConflictPolicy {
+	if policy, ok := w.GeneratorPolicies[gen.Name()]; ok {
+		return policy
+	}
+	return w.Policy
+}
+
+// Plan compares generated files with the filesystem without writing anything.
+// Files are returned sorted by path.
+func (w *Writer) Plan(targetPath string, files map[string]string) ([]PlannedFile, error) {
+	paths := make([]string, 0, len(files))
+	for relPath := range files {
+		paths = append(paths, relPath)
+	}
+	sort.Strings(paths)
+
+	planned := make([]PlannedFile, 0, len(paths))
+	for _, relPath := range paths {
+		fullPath := filepath.Join(targetPath, relPath)
+		pf := PlannedFile{Path: relPath, Content: files[relPath]}
+
+		data, err := w.FS.ReadFile(fullPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			pf.Status = StatusNew
+			planned = append(planned, pf)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", fullPath, err)
+		}
+		pf.Current = string(data)
+
+		// Only replace managed regions when both versions use them
+		if HasManagedRegions(pf.Current) && HasManagedRegions(pf.Content) {
+			merged, err := MergeManagedRegions(pf.Current, pf.Content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to merge managed regions in %s: %w", relPath, err)
+			}
+			pf.Content = merged
+		}
+
+		if pf.Current == pf.Content {
+			pf.Status = StatusUnchanged
+		} else {
+			pf.Status = StatusChanged
+		}
+
+		planned = append(planned, pf)
+	}
+	return planned, nil
+}
+
+// RunGenerator executes a generator and writes its output using the generator's conflict policy
+func (w *Writer) RunGenerator(gen Generator, ctx GenerateContext) ([]WriteResult, error) {
 	files, err := gen.Generate(ctx)
 	if err != nil {
-		return fmt.Errorf("generator %s failed: %w", gen.Name(), err)
+		return nil, fmt.Errorf("generator %s failed: %w", gen.Name(), err)
 	}
 
-	if err := w.WriteFiles(ctx.TargetPath, files); err != nil {
-		return fmt.Errorf("failed to write files for generator %s: %w", gen.Name(), err)
+	results, err := w.writeFiles(ctx.TargetPath, files, w.policyFor(gen))
+	if err != nil {
+		return nil, fmt.Errorf("failed to write files for generator %s: %w", gen.Name(), err)
 	}
 
-	return nil
+	if w.Manifest != nil {
+		configHash := HashConfig(ctx.Config)
+		for _, result := range results {
+			// Skipped files keep whatever the manifest knew about them
+			if result.Action != ActionSkipped {
+				w.Manifest.Record(result.Path, gen.Name(), configHash, files[result.Path], result.content)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// PreviewGenerator executes a generator and plans its output without writing it
+func (w *Writer) PreviewGenerator(gen Generator, ctx GenerateContext) ([]PlannedFile, error) {
+	files, err := gen.Generate(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("generator %s failed: %w", gen.Name(), err)
+	}
+
+	planned, err := w.Plan(ctx.TargetPath, files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan files for generator %s: %w", gen.Name(), err)
+	}
+
+	return planned, nil
 }
@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/mongoose84/proser/filesystem"
+	"github.com/mongoose84/proser/input"
+)
+
+func TestWriterPolicies(t *testing.T) {
+	const (
+		target    = "/project"
+		relPath   = "AGENTS.md"
+		generated = "generated\n"
+		edited    = "edited\n"
+	)
+
+	tests := []struct {
+		name        string
+		policy      ConflictPolicy
+		answer      string // prompt answer
+		existing    string // empty for a new file
+		wantAction  WriteAction
+		wantContent string
+		wantBackup  string // relative to the target
+	}{
+		{name: "new file", policy: PolicySkip, wantAction: ActionCreated, wantContent: generated},
+		{name: "unchanged file", policy: PolicySkip, existing: generated, wantAction: ActionUnchanged, wantContent: generated},
+		{name: "overwrite", policy: PolicyOverwrite, existing: edited, wantAction: ActionUpdated, wantContent: generated},
+		{name: "zero policy overwrites", existing: edited, wantAction: ActionUpdated, wantContent: generated},
+		{name: "skip", policy: PolicySkip, existing: edited, wantAction: ActionSkipped, wantContent: edited},
+		{name: "backup", policy: PolicyBackup, existing: edited, wantAction: ActionBackedUp, wantContent: generated, wantBackup: "AGENTS.md.bak"},
+		{name: "prompt yes", policy: PolicyPrompt, answer: "yes", existing: edited, wantAction: ActionUpdated, wantContent: generated},
+		{name: "prompt backup", policy: PolicyPrompt, answer: "b", existing: edited, wantAction: ActionBackedUp, wantContent: generated, wantBackup: "AGENTS.md.bak"},
+		{name: "prompt default keeps the file", policy: PolicyPrompt, existing: edited, wantAction: ActionSkipped, wantContent: edited},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := filesystem.NewMemoryFileSystem()
+			if tt.existing != "" {
+				writeFile(t, fsys, target, relPath, tt.existing)
+			}
+			w := NewWriter(fsys)
+			w.Policy = tt.policy
+			w.Prompter = input.NewFileCollector(map[string]string{relPath: tt.answer})
+
+			results, err := w.WriteFiles(target, map[string]string{relPath: generated})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := results[0].Action; got != tt.wantAction {
+				t.Errorf("Action = %q, want %q", got, tt.wantAction)
+			}
+			if got := readFile(t, fsys, filepath.Join(target, relPath)); got != tt.wantContent {
+				t.Errorf("content = %q, want %q", got, tt.wantContent)
+			}
+			if tt.wantBackup != "" {
+				if got := readFile(t, fsys, filepath.Join(target, tt.wantBackup)); got != tt.existing {
+					t.Errorf("backup content = %q, want %q", got, tt.existing)
+				}
+			}
+		})
+	}
+}
+
+func TestWriterKeepsEarlierBackups(t *testing.T) {
+	const target = "/project"
+
+	tests := []struct {
+		name       string
+		backupDir  string
+		wantFirst  string
+		wantSecond string
+	}{
+		{
+			name:       "next to the file",
+			wantFirst:  "AGENTS.md.bak",
+			wantSecond: "AGENTS.md.20260102-030405.bak",
+		},
+		{
+			name:       "backup directory of the same run",
+			backupDir:  ".proser/backups",
+			wantFirst:  ".proser/backups/20260102-030405/AGENTS.md",
+			wantSecond: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := filesystem.NewMemoryFileSystem()
+			w := NewWriter(fsys)
+			w.Policy = PolicyBackup
+			w.BackupDir = tt.backupDir
+			w.backupStamp = "20260102-030405"
+
+			writeFile(t, fsys, target, "AGENTS.md", "first edit\n")
+			if _, err := w.WriteFiles(target, map[string]string{"AGENTS.md": "generated\n"}); err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, fsys, target, "AGENTS.md", "second edit\n")
+			_, err := w.WriteFiles(target, map[string]string{"AGENTS.md": "generated\n"})
+			if tt.wantSecond == "" {
+				// Both backups of one run would land on the same path
+				if err == nil {
+					t.Error("second backup to the same path succeeded")
+				}
+			} else {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := readFile(t, fsys, filepath.Join(target, tt.wantSecond)); got != "second edit\n" {
+					t.Errorf("second backup = %q, want the second edit", got)
+				}
+			}
+			if got := readFile(t, fsys, filepath.Join(target, tt.wantFirst)); got != "first edit\n" {
+				t.Errorf("first backup = %q, want the first edit", got)
+			}
+		})
+	}
+}
+
+func TestWriterOverwritesUnmodifiedFiles(t *testing.T) {
+	const target = "/project"
+	fsys := filesystem.NewMemoryFileSystem()
+	w := NewWriter(fsys)
+	w.Policy = PolicySkip
+	w.Manifest = NewManifest()
+
+	ctx := GenerateContext{TargetPath: target, FS: fsys}
+	if _, err := w.RunGenerator(staticGenerator{"AGENTS.md": "v1\n"}, ctx); err != nil {
+		t.Fatal(err)
+	}
+	results, err := w.RunGenerator(staticGenerator{"AGENTS.md": "v2\n"}, ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Action != ActionUpdated {
+		t.Errorf("Action = %q, want %q for a file nobody edited", results[0].Action, ActionUpdated)
+	}
+}
+
+func readFile(t *testing.T, fsys filesystem.FileSystem, fullPath string) string {
+	t.Helper()
+	data, err := fsys.ReadFile(fullPath)
+	if err != nil {
+		t.Fatalf("reading %s: %v", fullPath, err)
+	}
+	return string(data)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Question represents a single input question
@@ -12,6 +14,9 @@ type Question struct {
 	Key          string
 	Prompt       string
 	DefaultValue string
+	// Validate optionally checks an interactive answer. A rejected answer is reported and
+	// the question asked again; giving the same answer twice keeps it anyway.
+	Validate func(answer string) error
 }
 
 // InputCollector abstracts user input collection for testability
@@ -20,15 +25,18 @@ type InputCollector interface {
 	Collect(questions []Question) (map[string]string, error)
 }
 
-// InteractiveCollector collects input interactively from a reader
+// InteractiveCollector collects input interactively from a reader,
+// writing prompts to a writer
 type InteractiveCollector struct {
 	reader *bufio.Reader
+	out    io.Writer
 }
 
 // NewInteractiveCollector creates a new interactive collector
-func NewInteractiveCollector(r io.Reader) *InteractiveCollector {
+func NewInteractiveCollector(r io.Reader, w io.Writer) *InteractiveCollector {
 	return &InteractiveCollector{
 		reader: bufio.NewReader(r),
+		out:    w,
 	}
 }
 
@@ -37,7 +45,7 @@ func (c *InteractiveCollector) Collect(questions []Question) (map[string]string,
 	answers := make(map[string]string)
 
 	for _, q := range questions {
-		answer, err := c.prompt(q.Prompt, q.DefaultValue)
+		answer, err := c.ask(q)
 		if err != nil {
 			return nil, fmt.Errorf("failed to collect input for %s: %w", q.Key, err)
 		}
@@ -47,13 +55,48 @@ func (c *InteractiveCollector) Collect(questions []Question) (map[string]string,
 	return answers, nil
 }
 
+// ask prompts for one question until its answer passes validation or is repeated
+func (c *InteractiveCollector) ask(q Question) (string, error) {
+	rejected := ""
+	var rejection error
+	for {
+		answer, err := c.prompt(q.Prompt, q.DefaultValue)
+		if err != nil {
+			// Without more input the default stands, unless the last answer was rejected
+			if rejection != nil {
+				return "", rejection
+			}
+			return answer, nil
+		}
+		if q.Validate == nil || answer == "" || answer == rejected {
+			return answer, nil
+		}
+		err = q.Validate(answer)
+		if err == nil {
+			return answer, nil
+		}
+		fmt.Fprintf(c.out, "⚠️  %s\n", capitalize(err.Error()))
+		fmt.Fprintln(c.out, "   Enter the same answer again to keep it.")
+		rejected, rejection = answer, err
+	}
+}
+
+// capitalize upper-cases the first letter of msg, which may be empty
+func capitalize(msg string) string {
+	r, size := utf8.DecodeRuneInString(msg)
+	if size == 0 {
+		return msg
+	}
+	return string(unicode.ToUpper(r)) + msg[size:]
+}
+
 // prompt asks a single question and returns the answer
 func (c *InteractiveCollector) prompt(prompt, defaultValue string) (string, error) {
-	fmt.Printf("%s [%s] (type 'skip' to omit): ", prompt, defaultValue)
+	fmt.Fprintf(c.out, "%s [%s] (type 'skip' to omit): ", prompt, defaultValue)
 	input, err := c.reader.ReadString('\n')
 	if err != nil {
-		// In case of read error, return default value
-		return defaultValue, nil
+		// In case of read error, return the default value along with the error
+		return defaultValue, err
 	}
 	input = strings.TrimSpace(input)
 	if strings.ToLower(input) == "skip" {
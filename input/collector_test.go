@@ -0,0 +1,103 @@
+package input
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestInteractiveCollectorValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		validate func(string) error
+		want     string
+		wantOut  string
+	}{
+		{
+			name:     "accepted answer",
+			input:    "Go\n",
+			validate: func(string) error { return nil },
+			want:     "Go",
+		},
+		{
+			name:  "rejected answer is asked again",
+			input: "Goo\nGo\n",
+			validate: func(answer string) error {
+				if answer != "Go" {
+					return errors.New("unknown language, did you mean Go?")
+				}
+				return nil
+			},
+			want:    "Go",
+			wantOut: "⚠️  Unknown language, did you mean Go?\n",
+		},
+		{
+			name:     "repeated answer is kept",
+			input:    "Goo\nGoo\n",
+			validate: func(string) error { return errors.New("élan is not a language") },
+			want:     "Goo",
+			wantOut:  "⚠️  Élan is not a language\n",
+		},
+		{
+			name:     "empty error message",
+			input:    "Goo\nGoo\n",
+			validate: func(string) error { return errors.New("") },
+			want:     "Goo",
+			wantOut:  "⚠️  \n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			c := NewInteractiveCollector(strings.NewReader(tt.input), &out)
+			answers, err := c.Collect([]Question{{Key: "language", Prompt: "Language", DefaultValue: "Go", Validate: tt.validate}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := answers["language"]; got != tt.want {
+				t.Errorf("answer = %q, want %q", got, tt.want)
+			}
+			if !strings.Contains(out.String(), tt.wantOut) {
+				t.Errorf("output %q does not contain %q", out.String(), tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestInteractiveCollectorRejectedAnswerAtEOF(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{name: "no input keeps the default", input: "", want: "Go"},
+		{name: "rejected answer", input: "Goo\n", wantErr: "unknown language"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewInteractiveCollector(strings.NewReader(tt.input), &strings.Builder{})
+			answers, err := c.Collect([]Question{{
+				Key:          "language",
+				Prompt:       "Language",
+				DefaultValue: "Go",
+				Validate:     func(string) error { return errors.New("unknown language") },
+			}})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Collect() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := answers["language"]; got != tt.want {
+				t.Errorf("answer = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
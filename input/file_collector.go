@@ -0,0 +1,104 @@
+package input
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mongoose84/proser/filesystem"
+	"gopkg.in/yaml.v3"
+)
+
+// FileCollector answers questions from a pre-loaded answers file instead of stdin.
+// Questions without an answer fall back to their DefaultValue.
+type FileCollector struct {
+	answers map[string]string
+}
+
+// NewFileCollector creates a new collector backed by the given answers
+func NewFileCollector(answers map[string]string) *FileCollector {
+	return &FileCollector{answers: answers}
+}
+
+// LoadAnswersFile reads a YAML or JSON answers file
+func LoadAnswersFile(fsys filesystem.FileSystem, path string) (map[string]string, error) {
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read answers file %s: %w", path, err)
+	}
+
+	answers, err := ParseAnswers(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse answers file %s: %w", path, err)
+	}
+	return answers, nil
+}
+
+// ParseAnswers parses a flat YAML or JSON document of question keys to answers.
+// JSON is a subset of YAML, so both formats go through the same decoder.
+func ParseAnswers(data []byte) (map[string]string, error) {
+	raw := make(map[string]interface{})
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	answers := make(map[string]string, len(raw))
+	for key, value := range raw {
+		switch v := value.(type) {
+		case nil:
+			answers[key] = ""
+		case string:
+			answers[key] = v
+		case bool:
+			if v {
+				answers[key] = "yes"
+			} else {
+				answers[key] = "no"
+			}
+		case int, int64, uint64, float64:
+			answers[key] = fmt.Sprint(v)
+		default:
+			return nil, fmt.Errorf("answer for %s must be a single value, got %T", key, value)
+		}
+	}
+	return answers, nil
+}
+
+// Collect answers every question from the file, using defaults for missing keys
+func (c *FileCollector) Collect(questions []Question) (map[string]string, error) {
+	answers := make(map[string]string)
+
+	for _, q := range questions {
+		answer, exists := c.answers[q.Key]
+		if !exists {
+			answer = q.DefaultValue
+		}
+		if strings.ToLower(strings.TrimSpace(answer)) == "skip" {
+			answer = ""
+		}
+		answers[q.Key] = answer
+	}
+
+	return answers, nil
+}
+
+// Validate returns an error listing every answer key that matches none of the known questions
+func (c *FileCollector) Validate(known []Question) error {
+	knownKeys := make(map[string]bool, len(known))
+	for _, q := range known {
+		knownKeys[q.Key] = true
+	}
+
+	var unknown []string
+	for key := range c.answers {
+		if !knownKeys[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+	return fmt.Errorf("unknown answer keys: %s", strings.Join(unknown, ", "))
+}
@@ -0,0 +1,40 @@
+package input
+
+// PresetCollector answers questions from preset values and forwards the rest
+// to another collector, so only questions without a preset answer are asked
+type PresetCollector struct {
+	preset map[string]string
+	next   InputCollector
+}
+
+// NewPresetCollector creates a collector that consults preset before next
+func NewPresetCollector(preset map[string]string, next InputCollector) *PresetCollector {
+	return &PresetCollector{preset: preset, next: next}
+}
+
+// Collect answers preset questions directly and asks next for the remaining ones
+func (c *PresetCollector) Collect(questions []Question) (map[string]string, error) {
+	answers := make(map[string]string)
+
+	var remaining []Question
+	for _, q := range questions {
+		if answer, exists := c.preset[q.Key]; exists {
+			answers[q.Key] = answer
+			continue
+		}
+		remaining = append(remaining, q)
+	}
+
+	if len(remaining) == 0 {
+		return answers, nil
+	}
+
+	asked, err := c.next.Collect(remaining)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range asked {
+		answers[k] = v
+	}
+	return answers, nil
+}
@@ -0,0 +1,62 @@
+package language
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+)
+
+// catalog holds the built-in language and framework definitions, one file per language
+// with its frameworks, in the same format as user and project definition files
+//
+//go:embed catalog/*.yaml catalog/schema.json
+var catalog embed.FS
+
+// NewDefaultRegistry creates a registry pre-populated with the built-in catalog of
+// languages and frameworks
+func NewDefaultRegistry() *Registry {
+	r, err := LoadDefaultRegistry()
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// LoadDefaultRegistry creates a registry pre-populated with the built-in catalog, returning
+// an error instead of panicking when the catalog is invalid
+func LoadDefaultRegistry() (*Registry, error) {
+	r := NewRegistry()
+	if err := r.loadCatalog(); err != nil {
+		return nil, fmt.Errorf("invalid built-in language catalog: %w", err)
+	}
+	return r, nil
+}
+
+// Schema returns the JSON Schema of language definition files, for validating the
+// built-in catalog and user or project definitions offline
+func Schema() []byte {
+	data, err := catalog.ReadFile("catalog/schema.json")
+	if err != nil {
+		panic(fmt.Sprintf("missing built-in language schema: %v", err))
+	}
+	return data
+}
+
+// loadCatalog merges every embedded definition file, in file name order
+func (r *Registry) loadCatalog() error {
+	files, err := fs.Glob(catalog, "catalog/*.yaml")
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := catalog.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if err := r.loadDefinitions(path.Base(file), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+package language
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mongoose84/proser/filesystem"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectDir is where a project keeps its own language and framework definitions,
+// relative to the project root
+const ProjectDir = ".proser/languages"
+
+// definitionFile is the layout of a language definition file, described by Schema.
+// Every field is optional; JSON files use the same keys.
+type definitionFile struct {
+	Languages  []languageDefinition  `yaml:"languages"`
+	Frameworks []frameworkDefinition `yaml:"frameworks"`
+}
+
+// languageDefinition adds a language or merges into the registered language of the same name
+type languageDefinition struct {
+	Name             string   `yaml:"name"`
+	Replace          bool     `yaml:"replace"` // drop the registered definition instead of merging into it
+	DisplayName      string   `yaml:"display_name"`
+	Aliases          []string `yaml:"aliases"`
+	FileExtensions   []string `yaml:"file_extensions"`
+	StyleExtensions  []string `yaml:"style_extensions"`
+	Guidelines       []string `yaml:"guidelines"`
+	BestPractices    []string `yaml:"best_practices"`
+	TestingPatterns  []string `yaml:"testing_patterns"`
+	OutputChecklist  []string `yaml:"output_checklist"`
+	ContextFiles     []string `yaml:"context_files"`
+	EntryPoints      []string `yaml:"entry_points"`
+	TestFilePatterns []string `yaml:"test_file_patterns"`
+	TestFramework    string   `yaml:"test_framework"`
+	Frontend         *bool    `yaml:"frontend"`
+}
+
+// frameworkDefinition adds a framework or merges into the registered framework of the same name
+type frameworkDefinition struct {
+	Name             string   `yaml:"name"`
+	Replace          bool     `yaml:"replace"`
+	Aliases          []string `yaml:"aliases"`
+	Language         string   `yaml:"language"`
+	Guidelines       []string `yaml:"guidelines"`
+	FileExtensions   []string `yaml:"file_extensions"`
+	StyleExtensions  []string `yaml:"style_extensions"`
+	TestFilePatterns []string `yaml:"test_file_patterns"`
+}
+
+// Clone returns a registry with the same definitions that can be extended independently
+func (r *Registry) Clone() *Registry {
+	clone := NewRegistry()
+	for key, lang := range r.languages {
+		clone.languages[key] = lang
+	}
+	for key, fw := range r.frameworks {
+		clone.frameworks[key] = fw
+	}
+	return clone
+}
+
+// LoadDir merges the definitions of every .yaml, .yml and .json file in dir into the
+// registry, in file name order. A missing directory is not an error.
+//
+// A definition whose name matches a registered language or framework is merged into it:
+// scalar fields that are set replace the registered values and list entries are appended,
+// skipping duplicates. With replace: true the definition takes the place of the registered
+// one entirely. Anything else is registered as a new language or framework.
+func (r *Registry) LoadDir(fsys filesystem.FileSystem, dir string) error {
+	if _, err := fsys.Stat(dir); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to access %s: %w", dir, err)
+	}
+
+	var files []string
+	err := fsys.Walk(dir, func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if p != dir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(p)) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	sort.Strings(files)
+	for _, file := range files {
+		if err := r.LoadFile(fsys, file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadFile merges the definitions of one YAML or JSON file into the registry, as LoadDir
+// does. Errors name the file and the offending field.
+func (r *Registry) LoadFile(fsys filesystem.FileSystem, file string) error {
+	data, err := fsys.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	return r.loadDefinitions(file, data)
+}
+
+// loadDefinitions merges the definitions in data, read from file, into the registry
+func (r *Registry) loadDefinitions(file string, data []byte) error {
+	var defs definitionFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&defs); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+
+	for i, def := range defs.Languages {
+		if err := def.validate(); err != nil {
+			return fmt.Errorf("%s: languages[%d].%w", file, i, err)
+		}
+		r.mergeLanguage(def)
+	}
+	for i, def := range defs.Frameworks {
+		if err := def.validate(r); err != nil {
+			return fmt.Errorf("%s: frameworks[%d].%w", file, i, err)
+		}
+		r.mergeFramework(def)
+	}
+	return nil
+}
+
+// fieldError reports an invalid field; it is prefixed with the definition's position
+type fieldError struct {
+	field string
+	msg   string
+}
+
+func (e *fieldError) Error() string {
+	return e.field + ": " + e.msg
+}
+
+// validate checks a language definition before it touches the registry
+func (d languageDefinition) validate() error {
+	if normalizeName(d.Name) == "" {
+		return &fieldError{"name", "is required"}
+	}
+	checks := []error{
+		checkExtensions("file_extensions", d.FileExtensions),
+		checkExtensions("style_extensions", d.StyleExtensions),
+		checkText("guidelines", d.Guidelines),
+		checkText("best_practices", d.BestPractices),
+		checkText("testing_patterns", d.TestingPatterns),
+		checkText("output_checklist", d.OutputChecklist),
+		checkGlobs("context_files", d.ContextFiles),
+		checkGlobs("entry_points", d.EntryPoints),
+		checkGlobs("test_file_patterns", d.TestFilePatterns),
+	}
+	return firstError(checks)
+}
+
+// validate checks a framework definition; its language must already be registered
+func (d frameworkDefinition) validate(r *Registry) error {
+	if normalizeName(d.Name) == "" {
+		return &fieldError{"name", "is required"}
+	}
+	_, exists := r.LookupFramework(d.Name)
+	if d.Language == "" && (!exists || d.Replace) {
+		return &fieldError{"language", "is required"}
+	}
+	if d.Language != "" {
+		if _, ok := r.LookupLanguage(d.Language); !ok {
+			return &fieldError{"language", fmt.Sprintf("unknown language %q", d.Language)}
+		}
+	}
+	checks := []error{
+		checkText("guidelines", d.Guidelines),
+		checkExtensions("file_extensions", d.FileExtensions),
+		checkExtensions("style_extensions", d.StyleExtensions),
+		checkGlobs("test_file_patterns", d.TestFilePatterns),
+	}
+	return firstError(checks)
+}
+
+// firstError returns the first non-nil error, so only one field is reported at a time
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkExtensions requires file extensions like ".go"
+func checkExtensions(field string, extensions []string) error {
+	for i, ext := range extensions {
+		if !strings.HasPrefix(ext, ".") || len(ext) == 1 || strings.ContainsAny(ext, "/*{} ") {
+			return &fieldError{fmt.Sprintf("%s[%d]", field, i), fmt.Sprintf("%q must be an extension such as \".go\"", ext)}
+		}
+	}
+	return nil
+}
+
+// plainText is a single line that does not start with a space or a list marker
+var plainText = regexp.MustCompile(`^[^\s-][^\n]*$`)
+
+// checkText requires single lines of plain text; the generators add the bullet or checkbox
+func checkText(field string, lines []string) error {
+	for i, line := range lines {
+		if !plainText.MatchString(line) {
+			return &fieldError{fmt.Sprintf("%s[%d]", field, i), fmt.Sprintf("%q must be a single line of text without a list marker", line)}
+		}
+	}
+	return nil
+}
+
+// checkGlobs requires relative slash-separated globs
+func checkGlobs(field string, globs []string) error {
+	for i, glob := range globs {
+		if glob == "" || strings.HasPrefix(glob, "/") || strings.Contains(glob, `\`) {
+			return &fieldError{fmt.Sprintf("%s[%d]", field, i), fmt.Sprintf("%q must be a relative path using \"/\"", glob)}
+		}
+		if _, err := path.Match(glob, ""); err != nil {
+			return &fieldError{fmt.Sprintf("%s[%d]", field, i), fmt.Sprintf("%q is not a valid glob", glob)}
+		}
+	}
+	return nil
+}
+
+// mergeLanguage registers a definition, merged into the language of the same name if there is one
+func (r *Registry) mergeLanguage(d languageDefinition) {
+	lang := &LanguageInfo{Name: normalizeName(d.Name)}
+	existing, exists := r.LookupLanguage(d.Name)
+	if exists {
+		r.unregisterLanguage(existing)
+		if d.Replace {
+			// A replacement keeps the registered names, so configs and frameworks naming
+			// the language still resolve when the definition used an alias
+			lang.Name = existing.Name
+			lang.DisplayName = existing.DisplayName
+			lang.Aliases = appendNew(nil, renamedAlias(d.Name, existing.Name))
+		} else {
+			copied := *existing
+			lang = &copied
+		}
+	}
+
+	if d.DisplayName != "" {
+		lang.DisplayName = d.DisplayName
+	}
+	if lang.DisplayName == "" {
+		lang.DisplayName = strings.TrimSpace(d.Name)
+	}
+	if d.TestFramework != "" {
+		lang.TestFramework = d.TestFramework
+	}
+	if d.Frontend != nil {
+		lang.Frontend = *d.Frontend
+	}
+	lang.Aliases = appendNew(lang.Aliases, d.Aliases)
+	lang.FileExtensions = appendNew(lang.FileExtensions, d.FileExtensions)
+	lang.StyleExtensions = appendNew(lang.StyleExtensions, d.StyleExtensions)
+	lang.Guidelines = appendNew(lang.Guidelines, d.Guidelines)
+	lang.BestPractices = appendNew(lang.BestPractices, d.BestPractices)
+	lang.TestingPatterns = appendNew(lang.TestingPatterns, d.TestingPatterns)
+	lang.OutputChecklist = appendNew(lang.OutputChecklist, d.OutputChecklist)
+	lang.ContextFiles = appendNew(lang.ContextFiles, d.ContextFiles)
+	lang.EntryPoints = appendNew(lang.EntryPoints, d.EntryPoints)
+	lang.TestFilePatterns = appendNew(lang.TestFilePatterns, d.TestFilePatterns)
+	r.RegisterLanguage(lang)
+}
+
+// mergeFramework registers a definition, merged into the framework of the same name if there is one
+func (r *Registry) mergeFramework(d frameworkDefinition) {
+	fw := &FrameworkInfo{Name: normalizeName(d.Name)}
+	existing, exists := r.LookupFramework(d.Name)
+	if exists {
+		r.unregisterFramework(existing)
+		if d.Replace {
+			fw.Name = existing.Name
+			fw.Aliases = appendNew(nil, renamedAlias(d.Name, existing.Name))
+		} else {
+			copied := *existing
+			fw = &copied
+		}
+	}
+
+	if d.Language != "" {
+		lang, _ := r.LookupLanguage(d.Language)
+		fw.Language = lang.Name
+	}
+	fw.Aliases = appendNew(fw.Aliases, d.Aliases)
+	fw.Guidelines = appendNew(fw.Guidelines, d.Guidelines)
+	fw.FileExtensions = appendNew(fw.FileExtensions, d.FileExtensions)
+	fw.StyleExtensions = appendNew(fw.StyleExtensions, d.StyleExtensions)
+	fw.TestFilePatterns = appendNew(fw.TestFilePatterns, d.TestFilePatterns)
+	r.RegisterFramework(fw)
+}
+
+// renamedAlias returns the name a replacing definition used when it differs from the
+// registered name, so the definition stays reachable under both
+func renamedAlias(defined, registered string) []string {
+	if name := normalizeName(defined); name != registered {
+		return []string{name}
+	}
+	return nil
+}
+
+// unregisterLanguage removes every name a language is registered under
+func (r *Registry) unregisterLanguage(lang *LanguageInfo) {
+	for key, registered := range r.languages {
+		if registered == lang {
+			delete(r.languages, key)
+		}
+	}
+}
+
+// unregisterFramework removes every name a framework is registered under
+func (r *Registry) unregisterFramework(fw *FrameworkInfo) {
+	for key, registered := range r.frameworks {
+		if registered == fw {
+			delete(r.frameworks, key)
+		}
+	}
+}
+
+// appendNew returns a new slice with the entries of add that list does not contain yet
+func appendNew(list, add []string) []string {
+	result := append([]string(nil), list...)
+	for _, entry := range add {
+		found := false
+		for _, existing := range result {
+			if existing == entry {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
@@ -0,0 +1,258 @@
+package language
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mongoose84/proser/filesystem"
+)
+
+func TestLoadLanguageDefinitions(t *testing.T) {
+	tests := []struct {
+		name            string
+		yaml            string
+		lookup          string
+		wantName        string
+		wantDisplayName string
+		wantAliases     []string
+		wantGuidelines  []string
+		wantGone        []string // names that no longer resolve
+	}{
+		{
+			name:            "new language",
+			yaml:            "languages:\n  - name: Zig\n    aliases: [ziglang]\n    guidelines: [\"Use comptime\"]\n",
+			lookup:          "ziglang",
+			wantName:        "zig",
+			wantDisplayName: "Zig",
+			wantAliases:     []string{"ziglang"},
+			wantGuidelines:  []string{"Use comptime"},
+		},
+		{
+			name:            "merge through an alias appends new entries",
+			yaml:            "languages:\n  - name: golang\n    aliases: [go-lang]\n    guidelines: [\"Wrap errors\", \"Prefer table-driven tests\"]\n",
+			lookup:          "go",
+			wantName:        "go",
+			wantDisplayName: "Go",
+			wantAliases:     []string{"golang", "go-lang"},
+			wantGuidelines:  []string{"Wrap errors"},
+		},
+		{
+			name:            "replace drops the registered definition",
+			yaml:            "languages:\n  - name: javascript\n    replace: true\n    guidelines: [\"Use the house style\"]\n",
+			lookup:          "javascript",
+			wantName:        "javascript",
+			wantDisplayName: "JavaScript",
+			wantGuidelines:  []string{"Use the house style"},
+			wantGone:        []string{"js", "node"},
+		},
+		{
+			name:            "replace through an alias keeps the registered name",
+			yaml:            "languages:\n  - name: js\n    replace: true\n    guidelines: [\"Use the house style\"]\n",
+			lookup:          "javascript",
+			wantName:        "javascript",
+			wantDisplayName: "JavaScript",
+			wantAliases:     []string{"js"},
+			wantGuidelines:  []string{"Use the house style"},
+			wantGone:        []string{"node"},
+		},
+		{
+			name:            "replace can set a new display name",
+			yaml:            "languages:\n  - name: golang\n    replace: true\n    display_name: Golang\n",
+			lookup:          "golang",
+			wantName:        "go",
+			wantDisplayName: "Golang",
+			wantAliases:     []string{"golang"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewDefaultRegistry()
+			if err := loadYAML(r, tt.yaml); err != nil {
+				t.Fatal(err)
+			}
+			lang, ok := r.LookupLanguage(tt.lookup)
+			if !ok {
+				t.Fatalf("LookupLanguage(%q) found nothing", tt.lookup)
+			}
+			if lang.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", lang.Name, tt.wantName)
+			}
+			if lang.DisplayName != tt.wantDisplayName {
+				t.Errorf("DisplayName = %q, want %q", lang.DisplayName, tt.wantDisplayName)
+			}
+			if !reflect.DeepEqual(lang.Aliases, tt.wantAliases) {
+				t.Errorf("Aliases = %q, want %q", lang.Aliases, tt.wantAliases)
+			}
+			for _, guideline := range tt.wantGuidelines {
+				if count(lang.Guidelines, guideline) != 1 {
+					t.Errorf("Guidelines %q should contain %q once", lang.Guidelines, guideline)
+				}
+			}
+			if found, _ := r.LookupLanguage(lang.Name); found != lang {
+				t.Errorf("LookupLanguage(%q) does not find the merged language", lang.Name)
+			}
+			for _, name := range tt.wantGone {
+				if _, ok := r.LookupLanguage(name); ok {
+					t.Errorf("LookupLanguage(%q) still resolves", name)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadFrameworkDefinitions(t *testing.T) {
+	tests := []struct {
+		name         string
+		yaml         string
+		lookup       string
+		wantName     string
+		wantLanguage string
+		wantAliases  []string
+		wantPatterns []string
+	}{
+		{
+			name:         "new framework takes the language's registered name",
+			yaml:         "frameworks:\n  - name: hono\n    language: ts\n",
+			lookup:       "hono",
+			wantName:     "hono",
+			wantLanguage: "typescript",
+		},
+		{
+			name:         "merge keeps the language",
+			yaml:         "frameworks:\n  - name: nextjs\n    test_file_patterns: [\"e2e/**\"]\n",
+			lookup:       "next.js",
+			wantName:     "next.js",
+			wantLanguage: "javascript",
+			wantAliases:  []string{"nextjs", "next"},
+			wantPatterns: []string{"e2e/**"},
+		},
+		{
+			name:         "replace through an alias keeps the registered name",
+			yaml:         "frameworks:\n  - name: sveltekit\n    replace: true\n    language: typescript\n",
+			lookup:       "svelte",
+			wantName:     "svelte",
+			wantLanguage: "typescript",
+			wantAliases:  []string{"sveltekit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewDefaultRegistry()
+			if err := loadYAML(r, tt.yaml); err != nil {
+				t.Fatal(err)
+			}
+			fw, ok := r.LookupFramework(tt.lookup)
+			if !ok {
+				t.Fatalf("LookupFramework(%q) found nothing", tt.lookup)
+			}
+			if fw.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", fw.Name, tt.wantName)
+			}
+			if fw.Language != tt.wantLanguage {
+				t.Errorf("Language = %q, want %q", fw.Language, tt.wantLanguage)
+			}
+			if !reflect.DeepEqual(fw.Aliases, tt.wantAliases) {
+				t.Errorf("Aliases = %q, want %q", fw.Aliases, tt.wantAliases)
+			}
+			if !reflect.DeepEqual(fw.TestFilePatterns, tt.wantPatterns) {
+				t.Errorf("TestFilePatterns = %q, want %q", fw.TestFilePatterns, tt.wantPatterns)
+			}
+		})
+	}
+}
+
+func TestLoadDefinitionErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			yaml:    "languages:\n  - name: zig\n    extensions: [.zig]\n",
+			wantErr: "field extensions not found",
+		},
+		{
+			name:    "missing name",
+			yaml:    "languages:\n  - display_name: Zig\n",
+			wantErr: "test.yaml: languages[0].name: is required",
+		},
+		{
+			name:    "extension without a dot",
+			yaml:    "languages:\n  - name: zig\n    file_extensions: [.zig, zon]\n",
+			wantErr: `test.yaml: languages[0].file_extensions[1]: "zon" must be an extension such as ".go"`,
+		},
+		{
+			name:    "guideline with a list marker",
+			yaml:    "languages:\n  - name: zig\n    guidelines: [\"- Use comptime\"]\n",
+			wantErr: `languages[0].guidelines[0]: "- Use comptime" must be a single line of text without a list marker`,
+		},
+		{
+			name:    "checklist line with a checkbox",
+			yaml:    "languages:\n  - name: zig\n    output_checklist: [\"- [ ] Tests\"]\n",
+			wantErr: `languages[0].output_checklist[0]: "- [ ] Tests" must be a single line of text without a list marker`,
+		},
+		{
+			name:    "multi-line guideline",
+			yaml:    "frameworks:\n  - name: gin\n    guidelines: [\"Keep handlers thin\\nand small\"]\n",
+			wantErr: `frameworks[0].guidelines[0]: "Keep handlers thin\nand small" must be a single line of text`,
+		},
+		{
+			name:    "absolute glob",
+			yaml:    "languages:\n  - name: zig\n    entry_points: [/src/main.zig]\n",
+			wantErr: `languages[0].entry_points[0]: "/src/main.zig" must be a relative path using "/"`,
+		},
+		{
+			name:    "invalid glob",
+			yaml:    "languages:\n  - name: zig\n    test_file_patterns: [\"src/[\"]\n",
+			wantErr: `languages[0].test_file_patterns[0]: "src/[" is not a valid glob`,
+		},
+		{
+			name:    "new framework without a language",
+			yaml:    "frameworks:\n  - name: hono\n",
+			wantErr: "frameworks[0].language: is required",
+		},
+		{
+			name:    "framework of an unknown language",
+			yaml:    "frameworks:\n  - name: hono\n    language: deno\n",
+			wantErr: `frameworks[0].language: unknown language "deno"`,
+		},
+		{
+			name:    "replacing framework without a language",
+			yaml:    "frameworks:\n  - name: react\n    replace: true\n",
+			wantErr: "frameworks[0].language: is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := loadYAML(NewDefaultRegistry(), tt.yaml)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadFile() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// loadYAML merges content into r through LoadFile, as a file named test.yaml
+func loadYAML(r *Registry, content string) error {
+	fsys := filesystem.NewMemoryFileSystem()
+	if err := fsys.WriteFile("test.yaml", []byte(content), 0644); err != nil {
+		return err
+	}
+	return r.LoadFile(fsys, "test.yaml")
+}
+
+// count returns how often entry occurs in list
+func count(list []string, entry string) int {
+	n := 0
+	for _, e := range list {
+		if e == entry {
+			n++
+		}
+	}
+	return n
+}
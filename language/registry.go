@@ -1,22 +1,37 @@
 package language
 
+import (
+	"sort"
+	"strings"
+)
+
 // LanguageInfo contains metadata and guidelines for a programming language
 type LanguageInfo struct {
-	Name            string
-	Aliases         []string // Alternative names (e.g., "js" for "javascript")
-	FileExtensions  []string // e.g., []string{".go"}
-	Guidelines      []string // Language-specific guideline lines
-	TestingPatterns []string // Language-specific testing pattern lines
-	ContextFiles    []string // Important context files (e.g., "go.mod", "package.json")
-	OutputChecklist []string // Structured output checklist items
-	BestPractices   []string // Best practice lines
+	Name             string
+	DisplayName      string   // Name as written in answers (e.g., "TypeScript")
+	Aliases          []string // Alternative names (e.g., "js" for "javascript")
+	FileExtensions   []string // e.g., []string{".go"}
+	StyleExtensions  []string // Stylesheets the frontend instructions also apply to (e.g., ".css")
+	Guidelines       []string // Language-specific guideline lines
+	TestingPatterns  []string // Language-specific testing pattern lines
+	ContextFiles     []string // Important context files (e.g., "go.mod", "package.json")
+	EntryPoints      []string // Application entry point globs (e.g., "cmd/*/main.go")
+	TestFilePatterns []string // applyTo globs matching test files (e.g., "**/*_test.go")
+	OutputChecklist  []string // Structured output checklist items
+	BestPractices    []string // Best practice lines
+	TestFramework    string   // Conventional testing framework (e.g., "Go testing")
+	Frontend         bool     // Whether the language is mostly used for user interfaces, in browsers or apps
 }
 
 // FrameworkInfo contains metadata and guidelines for a framework
 type FrameworkInfo struct {
-	Name       string
-	Language   string   // The language this framework is for
-	Guidelines []string // Framework-specific guideline lines
+	Name             string
+	Aliases          []string // Alternative names (e.g., "nextjs" for "next.js")
+	Language         string   // The language this framework is for
+	Guidelines       []string // Framework-specific guideline lines
+	FileExtensions   []string // Extensions the framework adds to its language's (e.g., ".vue")
+	StyleExtensions  []string // Stylesheets the framework adds to its language's (e.g., ".styl")
+	TestFilePatterns []string // applyTo globs matching test files, for testing frameworks
 }
 
 // Registry manages language and framework information
@@ -33,39 +48,51 @@ func NewRegistry() *Registry {
 	}
 }
 
-// RegisterLanguage adds a language to the registry
+// RegisterLanguage adds a language to the registry under its name, display name and aliases
 func (r *Registry) RegisterLanguage(lang *LanguageInfo) {
-	// Register by primary name
-	r.languages[lang.Name] = lang
-
-	// Register aliases
-	for _, alias := range lang.Aliases {
-		r.languages[alias] = lang
+	for _, name := range append([]string{lang.Name, lang.DisplayName}, lang.Aliases...) {
+		if key := normalizeName(name); key != "" {
+			r.languages[key] = lang
+		}
 	}
 }
 
-// RegisterFramework adds a framework to the registry
+// RegisterFramework adds a framework to the registry under its name and aliases
 func (r *Registry) RegisterFramework(fw *FrameworkInfo) {
-	r.frameworks[fw.Name] = fw
+	for _, name := range append([]string{fw.Name}, fw.Aliases...) {
+		if key := normalizeName(name); key != "" {
+			r.frameworks[key] = fw
+		}
+	}
 }
 
-// LookupLanguage finds a language by name or alias (case-insensitive)
+// LookupLanguage finds a language by name, display name or alias (case-insensitive)
 func (r *Registry) LookupLanguage(name string) (*LanguageInfo, bool) {
-	// Normalize to lowercase for lookup
-	lang, exists := r.languages[name]
+	lang, exists := r.languages[normalizeName(name)]
 	return lang, exists
 }
 
-// LookupFramework finds a framework by name (case-insensitive)
+// LookupFramework finds a framework by name or alias (case-insensitive)
 func (r *Registry) LookupFramework(name string) (*FrameworkInfo, bool) {
-	fw, exists := r.frameworks[name]
+	fw, exists := r.frameworks[normalizeName(name)]
 	return fw, exists
 }
 
-// NewDefaultRegistry creates a registry pre-populated with common languages and frameworks
-func NewDefaultRegistry() *Registry {
-	r := NewRegistry()
-	registerDefaultLanguages(r)
-	registerDefaultFrameworks(r)
-	return r
+// normalizeName turns a name as typed by a user into a registry key
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Languages returns every registered language once, sorted by name
+func (r *Registry) Languages() []*LanguageInfo {
+	seen := make(map[*LanguageInfo]bool)
+	var langs []*LanguageInfo
+	for _, lang := range r.languages {
+		if !seen[lang] {
+			seen[lang] = true
+			langs = append(langs, lang)
+		}
+	}
+	sort.Slice(langs, func(i, j int) bool { return langs[i].Name < langs[j].Name })
+	return langs
 }
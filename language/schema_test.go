@@ -0,0 +1,220 @@
+package language
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// schemaNode is the subset of JSON Schema that schema.json uses. Decoding rejects any
+// other keyword, so the schema cannot grow rules these tests ignore.
+type schemaNode struct {
+	Schema               string                 `json:"$schema"`
+	Title                string                 `json:"title"`
+	Description          string                 `json:"description"`
+	Ref                  string                 `json:"$ref"`
+	Type                 string                 `json:"type"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Required             []string               `json:"required"`
+	Items                *schemaNode            `json:"items"`
+	Pattern              string                 `json:"pattern"`
+	Definitions          map[string]*schemaNode `json:"definitions"`
+}
+
+func loadSchema(t *testing.T) *schemaNode {
+	t.Helper()
+	decoder := json.NewDecoder(bytes.NewReader(Schema()))
+	decoder.DisallowUnknownFields()
+	var root schemaNode
+	if err := decoder.Decode(&root); err != nil {
+		t.Fatalf("decoding schema.json: %v", err)
+	}
+	return &root
+}
+
+// validate checks value against node and returns the first violation
+func (root *schemaNode) validate(node *schemaNode, value any, at string) error {
+	if node.Ref != "" {
+		def, ok := root.Definitions[strings.TrimPrefix(node.Ref, "#/definitions/")]
+		if !ok {
+			return fmt.Errorf("%s: unknown $ref %q", at, node.Ref)
+		}
+		node = def
+	}
+
+	switch node.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: want an object, got %T", at, value)
+		}
+		for _, key := range node.Required {
+			if _, ok := object[key]; !ok {
+				return fmt.Errorf("%s: %s is required", at, key)
+			}
+		}
+		for key, child := range object {
+			property, ok := node.Properties[key]
+			if !ok {
+				if node.AdditionalProperties != nil && !*node.AdditionalProperties {
+					return fmt.Errorf("%s: unknown property %s", at, key)
+				}
+				continue
+			}
+			if err := root.validate(property, child, at+"."+key); err != nil {
+				return err
+			}
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: want an array, got %T", at, value)
+		}
+		for i, item := range items {
+			if err := root.validate(node.Items, item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: want a string, got %T", at, value)
+		}
+		if node.Pattern != "" && !regexp.MustCompile(node.Pattern).MatchString(s) {
+			return fmt.Errorf("%s: %q does not match %s", at, s, node.Pattern)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: want a boolean, got %T", at, value)
+		}
+	default:
+		return fmt.Errorf("%s: unsupported type %q", at, node.Type)
+	}
+	return nil
+}
+
+func TestCatalogMatchesSchema(t *testing.T) {
+	root := loadSchema(t)
+	files, err := fs.Glob(catalog, "catalog/*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		t.Run(file, func(t *testing.T) {
+			data, err := catalog.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var value map[string]any
+			if err := yaml.Unmarshal(data, &value); err != nil {
+				t.Fatal(err)
+			}
+			if err := root.validate(root, value, file); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSchemaMatchesDefinitionFields(t *testing.T) {
+	root := loadSchema(t)
+	tests := []struct {
+		name string
+		node *schemaNode
+		def  any
+	}{
+		{"file", root, definitionFile{}},
+		{"language", root.Definitions["language"], languageDefinition{}},
+		{"framework", root.Definitions["framework"], frameworkDefinition{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var want []string
+			fields := reflect.TypeOf(tt.def)
+			for i := 0; i < fields.NumField(); i++ {
+				want = append(want, fields.Field(i).Tag.Get("yaml"))
+			}
+			var got []string
+			for key := range tt.node.Properties {
+				got = append(got, key)
+			}
+			sort.Strings(want)
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("schema properties = %q, loader fields = %q", got, want)
+			}
+			if tt.node.AdditionalProperties == nil || *tt.node.AdditionalProperties {
+				t.Error("schema allows unknown properties, the loader rejects them")
+			}
+		})
+	}
+
+	for _, name := range []string{"language", "framework"} {
+		if got := root.Definitions[name].Required; !reflect.DeepEqual(got, []string{"name"}) {
+			t.Errorf("%s requires %q, the loader requires only name", name, got)
+		}
+	}
+}
+
+func TestSchemaPatternsMatchLoader(t *testing.T) {
+	root := loadSchema(t)
+	tests := []struct {
+		definition string
+		check      func(value string) error
+		values     []string
+	}{
+		{
+			definition: "name",
+			check: func(value string) error {
+				if normalizeName(value) == "" {
+					return fmt.Errorf("name is required")
+				}
+				return nil
+			},
+			values: []string{"go", "Next.js", "", " ", "\t"},
+		},
+		{
+			definition: "extension",
+			check:      func(value string) error { return checkExtensions("field", []string{value}) },
+			values:     []string{".go", ".d.ts", "go", ".", "", ".{ts,tsx}", ".a b", "./go", ".*"},
+		},
+		{
+			definition: "text",
+			check:      func(value string) error { return checkText("field", []string{value}) },
+			values:     []string{"Use `gofmt`", "[ ] Tests", "- Bullet", "- [ ] Box", "-flag", " Indented", "", "Two\nlines", "Ends with a dash -"},
+		},
+		{
+			// Only paths that are valid globs: the schema cannot express glob syntax
+			definition: "glob",
+			check:      func(value string) error { return checkGlobs("field", []string{value}) },
+			values:     []string{"go.mod", "cmd/*/main.go", "**/*_test.go", "src/", "", "/src/main.go", `src\main.go`, "a/b\\c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.definition, func(t *testing.T) {
+			node, ok := root.Definitions[tt.definition]
+			if !ok {
+				t.Fatalf("schema.json has no %s definition", tt.definition)
+			}
+			pattern := regexp.MustCompile(node.Pattern)
+			for _, value := range tt.values {
+				schemaOK := pattern.MatchString(value)
+				loaderOK := tt.check(value) == nil
+				if schemaOK != loaderOK {
+					t.Errorf("%q: schema accepts = %v, loader accepts = %v", value, schemaOK, loaderOK)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package language
+
+import "sort"
+
+// maxSuggestions is the most names SuggestLanguages returns
+const maxSuggestions = 3
+
+// SuggestLanguages returns the display names of the registered languages closest to name,
+// best match first. A language is compared by its name, display name and aliases and is
+// only suggested when the edit distance is small for the length of the name.
+func (r *Registry) SuggestLanguages(name string) []string {
+	typed := normalizeName(name)
+	if typed == "" {
+		return nil
+	}
+	limit := len([]rune(typed)) / 3
+	if limit < 1 {
+		limit = 1
+	}
+
+	type candidate struct {
+		lang     *LanguageInfo
+		distance int
+	}
+	best := make(map[*LanguageInfo]int)
+	for key, lang := range r.languages {
+		d := editDistance(typed, key)
+		if d > limit {
+			continue
+		}
+		if prev, ok := best[lang]; !ok || d < prev {
+			best[lang] = d
+		}
+	}
+
+	candidates := make([]candidate, 0, len(best))
+	for lang, d := range best {
+		candidates = append(candidates, candidate{lang, d})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].lang.Name < candidates[j].lang.Name
+	})
+
+	var names []string
+	for _, c := range candidates {
+		if len(names) == maxSuggestions {
+			break
+		}
+		display := c.lang.DisplayName
+		if display == "" {
+			display = c.lang.Name
+		}
+		names = append(names, display)
+	}
+	return names
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -1,6 +1,8 @@
 package project
 
 import (
+	"sort"
+
 	"github.com/mongoose84/proser/generator"
 	"github.com/mongoose84/proser/input"
 )
@@ -28,12 +30,13 @@ func Register(pt ProjectType) {
 	registry[pt.Name()] = pt
 }
 
-// GetAll returns all registered project types
+// GetAll returns all registered project types, sorted by name
 func GetAll() []ProjectType {
 	types := make([]ProjectType, 0, len(registry))
 	for _, pt := range registry {
 		types = append(types, pt)
 	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Name() < types[j].Name() })
 	return types
 }
 
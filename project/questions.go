@@ -2,6 +2,40 @@ package project
 
 import "github.com/mongoose84/proser/input"
 
+// TypeQuestion returns the question used to select the project type
+func TypeQuestion() input.Question {
+	return input.Question{Key: "project_type", Prompt: "Enter project type (fullstack/frontend/backend)", DefaultValue: "fullstack"}
+}
+
+// AllQuestions returns every question proser knows about, de-duplicated by key.
+// It is used to validate answers supplied outside the interactive flow.
+func AllQuestions() []input.Question {
+	groups := [][]input.Question{
+		{TypeQuestion()},
+		generalQuestions(),
+		frontendQuestions(),
+		backendQuestions(),
+		testingQuestions(),
+		structureQuestions(),
+		agentsQuestions(),
+		promptsQuestions(),
+		specsQuestions(),
+	}
+
+	seen := make(map[string]bool)
+	var questions []input.Question
+	for _, group := range groups {
+		for _, q := range group {
+			if seen[q.Key] {
+				continue
+			}
+			seen[q.Key] = true
+			questions = append(questions, q)
+		}
+	}
+	return questions
+}
+
 // generalQuestions returns questions for general project configuration
 func generalQuestions() []input.Question {
 	return []input.Question{
@@ -40,6 +74,16 @@ func testingQuestions() []input.Question {
 	}
 }
 
+// structureQuestions returns questions for the repository structure shown in AGENTS.md
+func structureQuestions() []input.Question {
+	return []input.Question{
+		{Key: "tree_depth", Prompt: "Directory levels to show in the AGENTS.md repository tree", DefaultValue: "2"},
+		{Key: "nested_agents", Prompt: "Write scoped AGENTS.md files in module and service directories? (yes/no)", DefaultValue: "no"},
+		{Key: "nested_agents_depth", Prompt: "Deepest directory level that gets a scoped AGENTS.md", DefaultValue: "3"},
+		{Key: "nested_agents_exclude", Prompt: "Directories that never get a scoped AGENTS.md (comma-separated globs, e.g. examples, internal/legacy/*)", DefaultValue: "None"},
+	}
+}
+
 // agentsQuestions returns questions for agent configuration
 func agentsQuestions() []input.Question {
 	return []input.Question{
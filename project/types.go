@@ -26,14 +26,17 @@ func (p *FullstackProject) Generators() []generator.Generator {
 		&generator.PromptsGenerator{},
 		&generator.SpecsGenerator{},
 		&generator.AgentsMdGenerator{},
+		&generator.NestedAgentsMdGenerator{},
 	}
 }
 
 func (p *FullstackProject) Questions() []input.Question {
 	// All detailed questions for custom setup
 	questions := generalQuestions()
+	questions = append(questions, frontendQuestions()...)
 	questions = append(questions, backendQuestions()...)
 	questions = append(questions, testingQuestions()...)
+	questions = append(questions, structureQuestions()...)
 	questions = append(questions, agentsQuestions()...)
 	questions = append(questions, promptsQuestions()...)
 	questions = append(questions, specsQuestions()...)
@@ -61,13 +64,16 @@ func (p *FrontendProject) Generators() []generator.Generator {
 		&generator.PromptsGenerator{},
 		&generator.SpecsGenerator{},
 		&generator.AgentsMdGenerator{},
+		&generator.NestedAgentsMdGenerator{},
 	}
 }
 
 func (p *FrontendProject) Questions() []input.Question {
 	// All detailed questions for custom setup
 	questions := generalQuestions()
+	questions = append(questions, frontendQuestions()...)
 	questions = append(questions, testingQuestions()...)
+	questions = append(questions, structureQuestions()...)
 	questions = append(questions, agentsQuestions()...)
 	questions = append(questions, promptsQuestions()...)
 	questions = append(questions, specsQuestions()...)
@@ -95,13 +101,16 @@ func (p *BackendProject) Generators() []generator.Generator {
 		&generator.PromptsGenerator{},
 		&generator.SpecsGenerator{},
 		&generator.AgentsMdGenerator{},
+		&generator.NestedAgentsMdGenerator{},
 	}
 }
 
 func (p *BackendProject) Questions() []input.Question {
 	// All detailed questions for custom setup
 	questions := generalQuestions()
+	questions = append(questions, backendQuestions()...)
 	questions = append(questions, testingQuestions()...)
+	questions = append(questions, structureQuestions()...)
 	questions = append(questions, agentsQuestions()...)
 	questions = append(questions, promptsQuestions()...)
 	questions = append(questions, specsQuestions()...)